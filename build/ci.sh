@@ -12,7 +12,12 @@ export CGO_LDFLAGS="-L$(pwd -P)/lib -Wl,-rpath -Wl,$(pwd -P)/lib"
 
 if [[ "$(uname)" == MINGW* ]]; then
     # copy the dll or ld.exe fails
-    cp -vf lib/objectbox.dll /c/TDM-GCC-64/lib/
+    if [[ -d /c/TDM-GCC-64/lib ]]; then
+        cp -vf lib/objectbox.dll /c/TDM-GCC-64/lib/
+    fi
+
+    # Windows looks up DLLs in PATH (there's no rpath) when running the tests
+    export PATH="$(pwd -P)/lib:${PATH}"
 fi
 
 ./build/build.sh $args
\ No newline at end of file
@@ -0,0 +1,223 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+/*
+Downloads the prebuilt ObjectBox native library for the given target platform, e.g. when cross-compiling.
+
+Usage:
+
+	go run github.com/objectbox/objectbox-go/cmd/objectbox-download [flags]
+
+The target defaults to the GOOS, GOARCH and GOARM environment variables (as used by "go build") or the current platform.
+The library is extracted to the output directory ("objectboxlib" by default), into the "lib" and "include" folders.
+Pass the library location to the linker when building, e.g. for a Raspberry Pi:
+
+	go run github.com/objectbox/objectbox-go/cmd/objectbox-download -os linux -arch arm -arm 7
+	CGO_ENABLED=1 GOOS=linux GOARCH=arm GOARM=7 CC=arm-linux-gnueabihf-gcc \
+		CGO_LDFLAGS="-L$(pwd)/objectboxlib/lib" go build
+
+Available flags:
+
+	-os string
+	  	target operating system: linux, darwin or windows
+	-arch string
+	  	target architecture: amd64, 386, arm or arm64
+	-arm string
+	  	target ARM version for -arch arm: 6 or 7
+	-version string
+	  	ObjectBox C library version
+	-out string
+	  	output directory
+*/
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// defaultVersion is the version of the ObjectBox C library this version of ObjectBox Go is built against
+const defaultVersion = "0.15.1"
+
+const releasesUrl = "https://github.com/objectbox/objectbox-c/releases/download"
+
+func main() {
+	var targetOS = flag.String("os", envOrDefault("GOOS", runtime.GOOS), "target operating system: linux, darwin or windows")
+	var targetArch = flag.String("arch", envOrDefault("GOARCH", runtime.GOARCH), "target architecture: amd64, 386, arm or arm64")
+	var targetArm = flag.String("arm", envOrDefault("GOARM", "7"), "target ARM version for -arch arm: 6 or 7")
+	var version = flag.String("version", defaultVersion, "ObjectBox C library version")
+	var out = flag.String("out", "objectboxlib", "output directory")
+	flag.Parse()
+
+	if err := download(*targetOS, *targetArch, *targetArm, *version, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func envOrDefault(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// artifactName returns the name of the release archive for the given platform, as published by objectbox-c
+func artifactName(targetOS, targetArch, targetArm string) (string, error) {
+	switch targetOS {
+	case "linux":
+		switch targetArch {
+		case "amd64":
+			return "objectbox-linux-x64.tar.gz", nil
+		case "arm64":
+			return "objectbox-linux-aarch64.tar.gz", nil
+		case "arm":
+			if targetArm == "6" || targetArm == "5" {
+				return "objectbox-linux-armv6hf.tar.gz", nil
+			}
+			return "objectbox-linux-armv7hf.tar.gz", nil
+		}
+	case "darwin":
+		if targetArch == "amd64" || targetArch == "arm64" {
+			return "objectbox-macos-universal.zip", nil
+		}
+	case "windows":
+		switch targetArch {
+		case "amd64":
+			return "objectbox-windows-x64.zip", nil
+		case "386":
+			return "objectbox-windows-x86.zip", nil
+		}
+	}
+	return "", fmt.Errorf("there's no prebuilt ObjectBox library for %s/%s", targetOS, targetArch)
+}
+
+func download(targetOS, targetArch, targetArm, version, out string) error {
+	name, err := artifactName(targetOS, targetArch, targetArm)
+	if err != nil {
+		return err
+	}
+
+	var url = fmt.Sprintf("%s/v%s/%s", releasesUrl, version, name)
+	fmt.Printf("Downloading %s\n", url)
+
+	response, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("download failed: %s", response.Status)
+	}
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(name, ".zip") {
+		err = extractZip(data, out)
+	} else {
+		err = extractTarGz(data, out)
+	}
+	if err != nil {
+		return fmt.Errorf("can't extract %s: %s", name, err)
+	}
+
+	fmt.Printf("Extracted to %s; build with CGO_LDFLAGS=\"-L%s\"\n", out, filepath.Join(out, "lib"))
+	return nil
+}
+
+func extractTarGz(data []byte, out string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	var reader = tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			if err := writeFile(out, header.Name, os.FileMode(header.Mode), reader); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractZip(data []byte, out string) error {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		content, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(out, file.Name, file.Mode(), content)
+		content.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile writes an archive entry to the output directory, rejecting paths pointing outside of it
+func writeFile(out, name string, mode os.FileMode, content io.Reader) error {
+	var path = filepath.Join(out, filepath.FromSlash(name))
+	if !strings.HasPrefix(path, filepath.Clean(out)+string(os.PathSeparator)) {
+		return fmt.Errorf("invalid path in the archive: %s", name)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode|0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, content)
+	return err
+}
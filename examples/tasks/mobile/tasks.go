@@ -0,0 +1,92 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package mobile exposes the tasks example through an API that can be used from Android (or iOS) apps by building
+// it with "gomobile bind". The exported API only uses types supported by gobind, e.g. int64 instead of uint64 IDs.
+package mobile
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/objectbox/objectbox-go/examples/tasks/internal/model"
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// TaskList is an open task database
+type TaskList struct {
+	ob  *objectbox.ObjectBox
+	box *model.TaskBox
+}
+
+// Open opens the task database inside the given directory; pass the app-internal storage,
+// i.e. context.getFilesDir().getAbsolutePath() on Android.
+func Open(filesDir string) (*TaskList, error) {
+	if filesDir == "" {
+		return nil, errors.New("files directory must be given")
+	}
+
+	ob, err := objectbox.NewBuilder().Directory(filepath.Join(filesDir, "objectbox")).
+		Model(model.ObjectBoxModel()).BuildOrError()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskList{ob: ob, box: model.BoxForTask(ob)}, nil
+}
+
+// Close closes the database; the TaskList must not be used afterwards
+func (list *TaskList) Close() {
+	list.ob.Close()
+}
+
+// Add creates a new task and returns its ID
+func (list *TaskList) Add(text string) (int64, error) {
+	id, err := list.box.Put(&model.Task{Text: text, DateCreated: time.Now()})
+	return int64(id), err
+}
+
+// Finish marks the task with the given ID as done
+func (list *TaskList) Finish(id int64) error {
+	task, err := list.box.Get(uint64(id))
+	if err != nil {
+		return err
+	} else if task == nil {
+		return errors.New("task not found")
+	}
+
+	task.DateFinished = time.Now()
+	_, err = list.box.Put(task)
+	return err
+}
+
+// Text returns the text of the task with the given ID
+func (list *TaskList) Text(id int64) (string, error) {
+	task, err := list.box.Get(uint64(id))
+	if err != nil {
+		return "", err
+	} else if task == nil {
+		return "", errors.New("task not found")
+	}
+	return task.Text, nil
+}
+
+// Count returns the number of tasks
+func (list *TaskList) Count() (int64, error) {
+	count, err := list.box.Count()
+	return int64(count), err
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+)
+
+// AdminIsAvailable returns true if the loaded ObjectBox native library contains the Admin web UI.
+func AdminIsAvailable() bool {
+	return bool(C.obx_has_feature(C.OBXFeature_Admin))
+}
+
+// AdminOptions configures the Admin web UI, see NewAdmin()
+type AdminOptions struct {
+	// BindUri is the address the http-server listens on; defaults to "http://127.0.0.1:8081".
+	// Use port 0 (e.g. "http://127.0.0.1:0") to pick a free port, see Admin.Port().
+	BindUri string
+
+	// NumThreads is the number of threads serving requests; defaults to 4.
+	NumThreads uint
+
+	// Unsecured disables authentication, making the web UI accessible to anyone that can reach it.
+	Unsecured bool
+
+	// LogRequests logs information about the served requests, e.g. timing.
+	LogRequests bool
+}
+
+// Admin is a running Admin web UI (http-server) for browsing the database objects, see NewAdmin()
+type Admin struct {
+	cAdmin *C.OBX_admin
+}
+
+// NewAdmin starts the Admin web UI http-server for the given store.
+// The Admin must be closed before the store is closed.
+func NewAdmin(ob *ObjectBox, options AdminOptions) (*Admin, error) {
+	if !AdminIsAvailable() {
+		return nil, errors.New("the loaded ObjectBox native library doesn't include the Admin web UI")
+	}
+
+	// for native calls/createError()
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var cOptions = C.obx_admin_opt()
+	if cOptions == nil {
+		return nil, createError()
+	}
+
+	var rc = C.obx_admin_opt_store(cOptions, ob.store)
+	if rc == 0 && len(options.BindUri) > 0 {
+		var cUri = C.CString(options.BindUri)
+		defer C.free(unsafe.Pointer(cUri))
+		rc = C.obx_admin_opt_bind(cOptions, cUri)
+	}
+	if rc == 0 && options.NumThreads > 0 {
+		rc = C.obx_admin_opt_num_threads(cOptions, C.size_t(options.NumThreads))
+	}
+	if rc == 0 && options.Unsecured {
+		rc = C.obx_admin_opt_unsecured_no_authentication(cOptions, C.bool(true))
+	}
+	if rc == 0 && options.LogRequests {
+		rc = C.obx_admin_opt_log_requests(cOptions, C.bool(true))
+	}
+	if rc != 0 {
+		var err = createError()
+		C.obx_admin_opt_free(cOptions)
+		return nil, err
+	}
+
+	// cOptions is consumed by obx_admin() so no need to free it
+	var admin = &Admin{cAdmin: C.obx_admin(cOptions)}
+	if admin.cAdmin == nil {
+		return nil, createError()
+	}
+	return admin, nil
+}
+
+// Port returns the port the Admin http-server listens on.
+func (admin *Admin) Port() uint16 {
+	return uint16(C.obx_admin_port(admin.cAdmin))
+}
+
+// Close stops the Admin http-server and frees the resources.
+func (admin *Admin) Close() error {
+	if admin.cAdmin == nil {
+		return nil
+	}
+
+	return cCall(func() C.obx_err {
+		defer func() { admin.cAdmin = nil }()
+		return C.obx_admin_close(admin.cAdmin)
+	})
+}
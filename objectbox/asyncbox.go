@@ -23,6 +23,7 @@ package objectbox
 import "C"
 import (
 	"errors"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -93,21 +94,24 @@ func (async *AsyncBox) put(object interface{}, mode int) (uint64, error) {
 			" relations because it could result in partial inserts/broken relations")
 	}
 
-	id, err := async.box.idForPut(idFromObject)
-	if err != nil {
-		return 0, err
-	}
-
-	err = async.box.withObjectBytes(object, id, func(bytes []byte) error {
-		return cCall(func() C.obx_err {
-			return C.obx_async_put5(async.cAsync, C.obx_id(id), unsafe.Pointer(&bytes[0]), C.size_t(len(bytes)),
-				C.OBXPutMode(mode))
+	var id uint64
+	err = async.box.ObjectBox.guard(func() error {
+		if id, err = async.box.idForPut(idFromObject); err != nil {
+			return err
+		}
+
+		return async.box.withObjectBytes(object, id, func(bytes []byte) error {
+			return cCall(func() C.obx_err {
+				return C.obx_async_put5(async.cAsync, C.obx_id(id), unsafe.Pointer(&bytes[0]), C.size_t(len(bytes)),
+					C.OBXPutMode(mode))
+			})
 		})
 	})
 
 	if err != nil {
 		return 0, err
 	}
+	atomic.AddUint64(&async.box.asyncSubmitted, 1)
 
 	// update the id on the object
 	if idFromObject != id {
@@ -151,9 +155,15 @@ func (async *AsyncBox) Remove(object interface{}) error {
 
 // RemoveId deletes a single object asynchronously.
 func (async *AsyncBox) RemoveId(id uint64) error {
-	return cCall(func() C.obx_err {
-		return C.obx_async_remove(async.cAsync, C.obx_id(id))
+	var err = async.box.ObjectBox.guard(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_async_remove(async.cAsync, C.obx_id(id))
+		})
 	})
+	if err == nil {
+		atomic.AddUint64(&async.box.asyncSubmitted, 1)
+	}
+	return err
 }
 
 // AwaitCompletion waits for all (including future) async submissions to be completed (the async queue becomes idle for
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dataFileName is the name of the database file inside the store directory
+const dataFileName = "data.mdb"
+
+// Backup writes a consistent snapshot of the database to the given file, while the store stays open.
+// Writes (including async ones) are blocked while the backup is being written, reads are not affected.
+// The backup contains all data stored in the database, including the schema version used by Builder.MigrateTo(),
+// so migration steps already applied aren't run again on a restored store.
+// Use Builder.FromBackup() to open a store from the backup file.
+func (ob *ObjectBox) Backup(path string) error {
+	if strings.HasPrefix(ob.directory, inMemoryPrefix) {
+		return fmt.Errorf("can't backup an in-memory database %q", ob.directory)
+	}
+
+	// an (empty) write transaction makes sure no other transaction changes the data file while it's being copied
+	return ob.RunInWriteTx(func() error {
+		return copyFile(filepath.Join(ob.directory, dataFileName), path)
+	})
+}
+
+// restoreBackup copies the backup file to the given store directory unless it already contains a database
+func restoreBackup(backupFile, directory string) error {
+	var dataFile = filepath.Join(directory, dataFileName)
+	if _, err := os.Stat(dataFile); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if _, err := os.Stat(backupFile); err != nil {
+		return fmt.Errorf("can't restore backup: %s", err)
+	}
+
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return err
+	}
+
+	// copy to a temporary file first so that an interrupted restore doesn't leave a broken database behind
+	if err := copyFile(backupFile, dataFile+".restore"); err != nil {
+		return err
+	}
+	return os.Rename(dataFile+".restore", dataFile)
+}
+
+func copyFile(sourcePath, targetPath string) (err error) {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	target, err := os.Create(targetPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errClose := target.Close(); err == nil {
+			err = errClose
+		}
+	}()
+
+	if _, err = io.Copy(target, source); err != nil {
+		return err
+	}
+	return target.Sync()
+}
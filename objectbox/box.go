@@ -23,10 +23,12 @@ package objectbox
 import "C"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"runtime"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/google/flatbuffers/go"
@@ -34,18 +36,28 @@ import (
 
 // Box provides CRUD access to objects of a common type
 type Box struct {
+	// counters of async operations submitted and awaited, see SetAsyncReadYourWrites;
+	// they're at the beginning of the struct because 64-bit atomic operations require 64-bit alignment
+	asyncSubmitted uint64
+	asyncAwaited   uint64
+	readYourWrites uint32
+
 	ObjectBox *ObjectBox
 	entity    *entity
 	cBox      *C.OBX_box
 	async     *AsyncBox
+
+	// softDelete is the property marking objects as deleted, see Builder.SoftDelete
+	softDelete *PropertyInt64
 }
 
 const defaultSliceCapacity = 16
 
 func newBox(ob *ObjectBox, entityId TypeId) (*Box, error) {
 	var box = &Box{
-		ObjectBox: ob,
-		entity:    ob.getEntityById(entityId),
+		ObjectBox:  ob,
+		entity:     ob.getEntityById(entityId),
+		softDelete: ob.softDelete[entityId],
 	}
 
 	if err := cCallBool(func() bool {
@@ -75,6 +87,42 @@ func (box *Box) Async() *AsyncBox {
 	return box.async
 }
 
+// SetAsyncReadYourWrites configures whether queries on this box first wait for the async operations previously
+// submitted for this entity (using Async() or NewAsyncBox()) to be processed, so the results include them (default: false).
+// This only waits if there were async operations since the last wait, otherwise there's no overhead.
+// Note: don't run queries inside a write transaction with this option enabled; async operations are executed in
+// their own write transactions and waiting for them would block forever.
+func (box *Box) SetAsyncReadYourWrites(enabled bool) {
+	var value uint32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreUint32(&box.readYourWrites, value)
+}
+
+// awaitPendingAsync waits for the async operations submitted for this box, if SetAsyncReadYourWrites is enabled
+func (box *Box) awaitPendingAsync() error {
+	if atomic.LoadUint32(&box.readYourWrites) == 0 {
+		return nil
+	}
+
+	var submitted = atomic.LoadUint64(&box.asyncSubmitted)
+	if submitted == atomic.LoadUint64(&box.asyncAwaited) {
+		return nil
+	}
+
+	if err := box.async.AwaitSubmitted(); err != nil {
+		return err
+	}
+
+	for {
+		var awaited = atomic.LoadUint64(&box.asyncAwaited)
+		if awaited >= submitted || atomic.CompareAndSwapUint64(&box.asyncAwaited, awaited, submitted) {
+			return nil
+		}
+	}
+}
+
 // Query creates a query with the given conditions. Use generated properties to create conditions.
 // Keep the Query object if you intend to execute it multiple times.
 // Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
@@ -89,23 +137,35 @@ func (box *Box) Query(conditions ...Condition) *Query {
 
 // QueryOrError is like Query() but with error handling; e.g. when you build conditions dynamically that may fail.
 func (box *Box) QueryOrError(conditions ...Condition) (query *Query, err error) {
-	builder := newQueryBuilder(box.ObjectBox, box.entity.id)
+	if box.softDelete != nil {
+		conditions = append(conditions[:len(conditions):len(conditions)], box.notDeleted())
+	}
+	return box.buildQuery(conditions)
+}
 
-	defer func() {
-		err2 := builder.Close()
-		if err == nil && err2 != nil {
-			err = err2
-			query = nil
-		}
-	}()
+func (box *Box) buildQuery(conditions []Condition) (query *Query, err error) {
+	err = box.ObjectBox.guard(func() (err error) {
+		builder := newQueryBuilder(box.ObjectBox, box.entity.id)
 
-	if err = builder.applyConditions(conditions); err != nil {
-		return nil, err
-	}
+		defer func() {
+			err2 := builder.Close()
+			if err == nil && err2 != nil {
+				err = err2
+				query = nil
+			}
+		}()
+
+		if err = builder.applyConditions(conditions); err != nil {
+			return err
+		}
 
-	query, err = builder.Build(box)
+		if query, err = builder.Build(box); err == nil {
+			query.conditions = conditions
+		}
 
-	return // NOTE result might be overwritten by the deferred "closer" function
+		return // NOTE result might be overwritten by the deferred "closer" function
+	})
+	return query, err
 }
 
 func (box *Box) idForPut(idCandidate uint64) (id uint64, err error) {
@@ -125,6 +185,21 @@ func (box *Box) idForPut(idCandidate uint64) (id uint64, err error) {
 	return
 }
 
+// IdsForPut reserves the given number of new IDs, e.g. to reference new objects from each other before putting them.
+// The reserved IDs are firstId, firstId+1, ..., firstId+count-1; set them on the objects before calling Put/PutMany.
+// At most 10000 IDs can be reserved in a single call.
+func (box *Box) IdsForPut(count int) (firstId uint64, err error) {
+	if count < 0 {
+		return 0, fmt.Errorf("invalid count %d", count)
+	}
+
+	err = box.ObjectBox.guard(func() error {
+		firstId, err = box.idsForPut(count)
+		return err
+	})
+	return firstId, err
+}
+
 func (box *Box) idsForPut(count int) (firstId uint64, err error) {
 	if count == 0 {
 		return 0, nil
@@ -142,36 +217,47 @@ func (box *Box) idsForPut(count int) (firstId uint64, err error) {
 }
 
 func (box *Box) put(object interface{}, alreadyInTx bool, putMode C.OBXPutMode) (id uint64, err error) {
-	idFromObject, err := box.entity.binding.GetId(object)
-	if err != nil {
-		return 0, err
-	}
-
-	if putMode == cPutModeUpdate {
-		id = idFromObject
-		if idFromObject == 0 {
-			return 0, errors.New("cannot update an object with ID 0 - if it's a new object use Put or Insert instead")
+	err = box.ObjectBox.guard(func() (err error) {
+		if err := box.ObjectBox.writeQueue.acquire(); err != nil {
+			return err
 		}
-	} else {
-		id, err = box.idForPut(idFromObject)
+		defer box.ObjectBox.writeQueue.release()
+
+		var obs = box.ObjectBox.observe(OperationPut, box.entity)
+		defer func() { obs.done(1, err) }()
+
+		idFromObject, err := box.entity.binding.GetId(object)
 		if err != nil {
-			return 0, err
+			return err
 		}
-	}
 
-	// for entities with relations, execute all Put/PutRelated inside a single transaction
-	if box.entity.hasRelations && !alreadyInTx {
-		err = box.ObjectBox.RunInWriteTx(func() error {
-			return box.putOne(id, object, putMode)
-		})
-	} else {
-		err = box.putOne(id, object, putMode)
-	}
+		if putMode == cPutModeUpdate {
+			id = idFromObject
+			if idFromObject == 0 {
+				return errors.New("cannot update an object with ID 0 - if it's a new object use Put or Insert instead")
+			}
+		} else {
+			id, err = box.idForPut(idFromObject)
+			if err != nil {
+				return err
+			}
+		}
 
-	// update the id on the object
-	if err == nil && idFromObject != id {
-		err = box.entity.binding.SetId(object, id)
-	}
+		// for entities with relations, execute all Put/PutRelated inside a single transaction
+		if box.entity.hasRelations && !alreadyInTx {
+			err = box.ObjectBox.RunInWriteTx(func() error {
+				return box.putOne(id, object, putMode, obs)
+			})
+		} else {
+			err = box.putOne(id, object, putMode, obs)
+		}
+
+		// update the id on the object
+		if err == nil && idFromObject != id {
+			err = box.entity.binding.SetId(object, id)
+		}
+		return err
+	})
 
 	if err != nil {
 		id = 0
@@ -180,7 +266,7 @@ func (box *Box) put(object interface{}, alreadyInTx bool, putMode C.OBXPutMode)
 	return id, err
 }
 
-func (box *Box) putOne(id uint64, object interface{}, putMode C.OBXPutMode) error {
+func (box *Box) putOne(id uint64, object interface{}, putMode C.OBXPutMode, obs *observation) error {
 	if box.entity.hasRelations { // In that case, the caller already ensured to be inside a TX
 		if err := box.entity.binding.PutRelated(box.ObjectBox, object, id); err != nil {
 			return err
@@ -188,6 +274,7 @@ func (box *Box) putOne(id uint64, object interface{}, putMode C.OBXPutMode) erro
 	}
 
 	return box.withObjectBytes(object, id, func(bytes []byte) error {
+		obs.addBytes(len(bytes))
 		return cCall(func() C.obx_err {
 			return C.obx_box_put5(box.cBox, C.obx_id(id), unsafe.Pointer(&bytes[0]), C.size_t(len(bytes)), putMode)
 		})
@@ -195,6 +282,12 @@ func (box *Box) putOne(id uint64, object interface{}, putMode C.OBXPutMode) erro
 }
 
 func (box *Box) withObjectBytes(object interface{}, id uint64, fn func([]byte) error) error {
+	if hook, ok := object.(BeforePutHook); ok {
+		if err := hook.BeforePut(); err != nil {
+			return err
+		}
+	}
+
 	var fbb = fbbPool.Get().(*flatbuffers.Builder)
 
 	err := box.entity.binding.Flatten(object, fbb, id)
@@ -241,6 +334,89 @@ func (box *Box) Update(object interface{}) error {
 	return err
 }
 
+// PutIfAbsent inserts the object only if it's new (ID 0) or no object with the same ID is stored yet.
+// Returns the ID of the object and whether it was inserted; an existing stored object is left untouched.
+func (box *Box) PutIfAbsent(object interface{}) (id uint64, inserted bool, err error) {
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		idFromObject, err := box.entity.binding.GetId(object)
+		if err != nil {
+			return err
+		}
+
+		if idFromObject != 0 {
+			if exists, err := box.Contains(idFromObject); err != nil {
+				return err
+			} else if exists {
+				id = idFromObject
+				return nil
+			}
+		}
+
+		id, err = box.put(object, true, cPutModeInsert)
+		inserted = err == nil
+		return err
+	})
+
+	if err != nil {
+		return 0, false, err
+	}
+	return id, inserted, nil
+}
+
+// GetOrInsert returns the stored object having the same value of the given (usually unique) property as the given
+// object, or inserts the given object if there's none. Both happen in a single write transaction, so concurrent calls
+// can't insert duplicates. Returns the stored object (the given one if it was inserted) and whether it was inserted.
+// Note: the value is taken from the object as given, i.e. before its BeforePutHook is called.
+func (box *Box) GetOrInsert(object interface{}, property Property) (result interface{}, inserted bool, err error) {
+	if property == nil {
+		return nil, false, errors.New("property is not defined")
+	}
+
+	var prop = box.entity.property(property.propertyId())
+	if property.entityId() != box.entity.id || prop == nil {
+		return nil, false, fmt.Errorf("property %d doesn't belong to entity %s", property.propertyId(), box.entity.name)
+	}
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		var fbb = flatbuffers.NewBuilder(512)
+		if err := box.entity.binding.Flatten(object, fbb, 0); err != nil {
+			return err
+		}
+		fbb.Finish(fbb.EndObject())
+
+		var bytes = fbb.FinishedBytes()
+		value, err := prop.read(&flatbuffers.Table{Bytes: bytes, Pos: flatbuffers.GetUOffsetT(bytes)})
+		if err != nil {
+			return err
+		} else if value == nil {
+			return fmt.Errorf("property %s has no value to look up", prop.name)
+		}
+
+		// the value is in the representation used by DynamicPropertyConditions, which creates the matching condition
+		var lookup = &DynamicPropertyConditions{name: prop.name, prop: prop,
+			property: &BaseProperty{Entity: &Entity{Id: box.entity.id}}}
+		query, err := box.QueryOrError(lookup.Equals(value))
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+
+		if result, err = query.FindFirst(); err != nil || result != nil {
+			return err
+		}
+
+		if _, err = box.put(object, true, cPutModeInsert); err == nil {
+			result, inserted = object, true
+		}
+		return err
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+	return result, inserted, nil
+}
+
 // PutMany inserts multiple objects in a single transaction.
 // The given argument must be a slice of the object type this Box represents (pointers to objects).
 // In case IDs are not set on the objects, they would be assigned automatically (auto-increment).
@@ -252,6 +428,16 @@ func (box *Box) Update(object interface{}) error {
 //
 // Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
 func (box *Box) PutMany(objects interface{}) (ids []uint64, err error) {
+	return box.putMany(context.Background(), objects)
+}
+
+// PutManyCtx is like PutMany but checks the given context between chunks of objects.
+// If the context is done before all objects are written, the transaction is rolled back and ctx.Err() is returned.
+func (box *Box) PutManyCtx(ctx context.Context, objects interface{}) (ids []uint64, err error) {
+	return box.putMany(ctx, objects)
+}
+
+func (box *Box) putMany(ctx context.Context, objects interface{}) (ids []uint64, err error) {
 	var slice = reflect.ValueOf(objects)
 	var count = slice.Len()
 
@@ -276,18 +462,29 @@ func (box *Box) PutMany(objects interface{}) (ids []uint64, err error) {
 			}
 
 			for c := 0; c < chunks; c++ {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
 				var start = c * chunkSize
 				var end = start + chunkSize
 				if end > count {
 					end = count
 				}
 
-				if err := box.putManyObjects(slice, ids, start, end); err != nil {
+				var obs = box.ObjectBox.observe(OperationPut, box.entity)
+				var err = box.putManyObjects(slice, ids, start, end, obs)
+				obs.done(end-start, err)
+				if err != nil {
 					return err
 				}
 			}
 		} else {
 			for i := 0; i < count; i++ {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
 				id, err := box.put(slice.Index(i).Interface(), true, cPutModePut)
 				if err != nil {
 					return err
@@ -309,7 +506,7 @@ func (box *Box) PutMany(objects interface{}) (ids []uint64, err error) {
 // putManyObjects inserts a subset of objects, setting their IDs as an outArgument.
 // Requires to be called inside a write transaction, i.e. from the ObjectBox.RunInWriteTx() callback.
 // The caller of this method (PutMany) already sliced up the data into chunks to mitigate memory consumption.
-func (box *Box) putManyObjects(objects reflect.Value, outIds []uint64, start, end int) error {
+func (box *Box) putManyObjects(objects reflect.Value, outIds []uint64, start, end int, obs *observation) error {
 	var binding = box.entity.binding
 	var count = end - start
 
@@ -359,6 +556,7 @@ func (box *Box) putManyObjects(objects reflect.Value, outIds []uint64, start, en
 		if err := box.withObjectBytes(object, outIds[key], func(bytes []byte) error {
 			objectsBytes[i] = make([]byte, len(bytes))
 			copy(objectsBytes[i], bytes)
+			obs.addBytes(len(bytes))
 			return nil
 		}); err != nil {
 			return err
@@ -403,8 +601,28 @@ func (box *Box) Remove(object interface{}) error {
 
 // RemoveId deletes a single object
 func (box *Box) RemoveId(id uint64) error {
-	return cCall(func() C.obx_err {
-		return C.obx_box_remove(box.cBox, C.obx_id(id))
+	if box.softDelete != nil {
+		var obs = box.ObjectBox.observe(OperationRemove, box.entity)
+		count, err := box.softRemoveIds([]uint64{id})
+		if err == nil && count == 0 {
+			err = fmt.Errorf("object with ID %d doesn't exist or is already deleted", id)
+		}
+		obs.done(int(count), err)
+		return err
+	}
+
+	return box.ObjectBox.guard(func() error {
+		if err := box.ObjectBox.writeQueue.acquire(); err != nil {
+			return err
+		}
+		defer box.ObjectBox.writeQueue.release()
+
+		var obs = box.ObjectBox.observe(OperationRemove, box.entity)
+		var err = cCall(func() C.obx_err {
+			return C.obx_box_remove(box.cBox, C.obx_id(id))
+		})
+		obs.done(1, err)
+		return err
 	})
 }
 
@@ -413,15 +631,33 @@ func (box *Box) RemoveId(id uint64) error {
 // Note that this method will not fail if an object is not found (e.g. already removed).
 // In case you need to strictly check whether all of the objects exist before removing them,
 // you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
-func (box *Box) RemoveIds(ids ...uint64) (uint64, error) {
-	cIds, err := goIdsArrayToC(ids)
-	if err != nil {
-		return 0, err
+func (box *Box) RemoveIds(ids ...uint64) (count uint64, err error) {
+	var obs = box.ObjectBox.observe(OperationRemove, box.entity)
+	if box.softDelete != nil {
+		count, err = box.softRemoveIds(ids)
+	} else {
+		count, err = box.removeIds(ids)
 	}
+	obs.done(int(count), err)
+	return count, err
+}
 
+func (box *Box) removeIds(ids []uint64) (uint64, error) {
 	var cResult C.uint64_t
-	err = cCall(func() C.obx_err {
-		return C.obx_box_remove_many(box.cBox, cIds.cArray, &cResult)
+	var err = box.ObjectBox.guard(func() error {
+		if err := box.ObjectBox.writeQueue.acquire(); err != nil {
+			return err
+		}
+		defer box.ObjectBox.writeQueue.release()
+
+		cIds, err := goIdsArrayToC(ids)
+		if err != nil {
+			return err
+		}
+
+		return cCall(func() C.obx_err {
+			return C.obx_box_remove_many(box.cBox, cIds.cArray, &cResult)
+		})
 	})
 	return uint64(cResult), err
 }
@@ -429,12 +665,23 @@ func (box *Box) RemoveIds(ids ...uint64) (uint64, error) {
 // RemoveAll removes all stored objects.
 // This is much faster than removing objects one by one in a loop.
 func (box *Box) RemoveAll() error {
-	return cCall(func() C.obx_err {
-		return C.obx_box_remove_all(box.cBox, nil)
+	return box.ObjectBox.guard(func() error {
+		if err := box.ObjectBox.writeQueue.acquire(); err != nil {
+			return err
+		}
+		defer box.ObjectBox.writeQueue.release()
+
+		var obs = box.ObjectBox.observe(OperationRemove, box.entity)
+		var cResult C.uint64_t
+		var err = cCall(func() C.obx_err {
+			return C.obx_box_remove_all(box.cBox, &cResult)
+		})
+		obs.done(int(cResult), err)
+		return err
 	})
 }
 
-// Count returns a number of objects stored
+// Count returns a number of objects stored, including the ones marked as deleted (see Builder.SoftDelete)
 func (box *Box) Count() (uint64, error) {
 	return box.CountMax(0)
 }
@@ -443,7 +690,9 @@ func (box *Box) Count() (uint64, error) {
 // passing limit=0 is the same as calling Count() - counts all objects without a limit
 func (box *Box) CountMax(limit uint64) (uint64, error) {
 	var cResult C.uint64_t
-	if err := cCall(func() C.obx_err { return C.obx_box_count(box.cBox, C.uint64_t(limit), &cResult) }); err != nil {
+	if err := box.ObjectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_box_count(box.cBox, C.uint64_t(limit), &cResult) })
+	}); err != nil {
 		return 0, err
 	}
 	return uint64(cResult), nil
@@ -452,7 +701,9 @@ func (box *Box) CountMax(limit uint64) (uint64, error) {
 // IsEmpty checks whether the box contains any objects
 func (box *Box) IsEmpty() (bool, error) {
 	var cResult C.bool
-	if err := cCall(func() C.obx_err { return C.obx_box_is_empty(box.cBox, &cResult) }); err != nil {
+	if err := box.ObjectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_box_is_empty(box.cBox, &cResult) })
+	}); err != nil {
 		return false, err
 	}
 	return bool(cResult), nil
@@ -463,7 +714,17 @@ func (box *Box) IsEmpty() (bool, error) {
 // Returns an interface that should be cast to the appropriate type.
 // Returns nil in case the object with the given ID doesn't exist.
 // The cast is done automatically when using the generated BoxFor* code.
+// Objects marked as deleted (see Builder.SoftDelete) are returned as well.
 func (box *Box) Get(id uint64) (object interface{}, err error) {
+	var obs = box.ObjectBox.observe(OperationGet, box.entity)
+	defer func() {
+		var count = 0
+		if object != nil {
+			count = 1
+		}
+		obs.done(count, err)
+	}()
+
 	// we need a read-transaction to keep the data in dataPtr untouched (by concurrent write) until we can read it
 	// as well as making sure the relations read in binding.Load represent a consistent state
 	err = box.ObjectBox.RunInReadTx(func() error {
@@ -475,7 +736,7 @@ func (box *Box) Get(id uint64) (object interface{}, err error) {
 		if rc == 0 {
 			var bytes []byte
 			cVoidPtrToByteSlice(dataPtr, int(dataSize), &bytes)
-			object, err = box.entity.binding.Load(box.ObjectBox, bytes)
+			object, err = box.entity.load(box.ObjectBox, bytes)
 			return err
 		} else if rc == C.OBX_NOT_FOUND {
 			object = nil
@@ -491,6 +752,31 @@ func (box *Box) Get(id uint64) (object interface{}, err error) {
 	return object, err
 }
 
+// GetRaw calls the given function with the FlatBuffers data of the object with the given ID, without creating
+// the Go object. The bytes are only valid during the callback. Returns false if the object doesn't exist,
+// in which case the function isn't called.
+func (box *Box) GetRaw(id uint64, fn func(bytes []byte) error) (found bool, err error) {
+	err = box.ObjectBox.RunInReadTx(func() error {
+		var dataPtr unsafe.Pointer
+		var dataSize C.size_t
+
+		var rc = C.obx_box_get(box.cBox, C.obx_id(id), &dataPtr, &dataSize)
+		if rc == 0 {
+			found = true
+			var bytes []byte
+			cVoidPtrToByteSlice(dataPtr, int(dataSize), &bytes)
+			return fn(bytes)
+		} else if rc == C.OBX_NOT_FOUND {
+			return nil
+		} else {
+			// NOTE: no need for manual runtime.LockOSThread() because we're inside a read transaction
+			return createError()
+		}
+	})
+
+	return found && err == nil, err
+}
+
 // GetMany reads multiple objects at once.
 //
 // Returns a slice of objects that should be cast to the appropriate type.
@@ -498,6 +784,9 @@ func (box *Box) Get(id uint64) (object interface{}, err error) {
 // If any of the objects doesn't exist, its position in the return slice
 //  is nil or an empty object (depends on the binding)
 func (box *Box) GetMany(ids ...uint64) (slice interface{}, err error) {
+	var obs = box.ObjectBox.observe(OperationGet, box.entity)
+	defer func() { obs.done(sliceLen(slice), err) }()
+
 	const existingOnly = false
 	if cIds, err := goIdsArrayToC(ids); err != nil {
 		return nil, err
@@ -507,7 +796,7 @@ func (box *Box) GetMany(ids ...uint64) (slice interface{}, err error) {
 		var cFn = func(visitorArg unsafe.Pointer) C.obx_err {
 			return C.obx_box_visit_many(box.cBox, cIds.cArray, dataVisitor, visitorArg)
 		}
-		return box.readUsingVisitor(existingOnly, cFn)
+		return box.readUsingVisitor(context.Background(), existingOnly, 0, cFn)
 	}
 }
 
@@ -516,6 +805,9 @@ func (box *Box) GetMany(ids ...uint64) (slice interface{}, err error) {
 // Returns a slice of objects that should be cast to the appropriate type.
 // The cast is done automatically when using the generated BoxFor* code.
 func (box *Box) GetManyExisting(ids ...uint64) (slice interface{}, err error) {
+	var obs = box.ObjectBox.observe(OperationGet, box.entity)
+	defer func() { obs.done(sliceLen(slice), err) }()
+
 	const existingOnly = true
 	if cIds, err := goIdsArrayToC(ids); err != nil {
 		return nil, err
@@ -525,7 +817,7 @@ func (box *Box) GetManyExisting(ids ...uint64) (slice interface{}, err error) {
 		var cFn = func(visitorArg unsafe.Pointer) C.obx_err {
 			return C.obx_box_visit_many(box.cBox, cIds.cArray, dataVisitor, visitorArg)
 		}
-		return box.readUsingVisitor(existingOnly, cFn)
+		return box.readUsingVisitor(context.Background(), existingOnly, 0, cFn)
 	}
 }
 
@@ -533,7 +825,11 @@ func (box *Box) GetManyExisting(ids ...uint64) (slice interface{}, err error) {
 //
 // Returns a slice of objects that should be cast to the appropriate type.
 // The cast is done automatically when using the generated BoxFor* code.
+// Objects marked as deleted (see Builder.SoftDelete) are returned as well; use a query to exclude them.
 func (box *Box) GetAll() (slice interface{}, err error) {
+	var obs = box.ObjectBox.observe(OperationGet, box.entity)
+	defer func() { obs.done(sliceLen(slice), err) }()
+
 	const existingOnly = true
 	if supportsResultArray {
 		return box.readManyObjects(existingOnly, func() *C.OBX_bytes_array { return C.obx_box_get_all(box.cBox) })
@@ -542,7 +838,20 @@ func (box *Box) GetAll() (slice interface{}, err error) {
 	var cFn = func(visitorArg unsafe.Pointer) C.obx_err {
 		return C.obx_box_visit_all(box.cBox, dataVisitor, visitorArg)
 	}
-	return box.readUsingVisitor(existingOnly, cFn)
+	return box.readUsingVisitor(context.Background(), existingOnly, 0, cFn)
+}
+
+// GetAllCtx is like GetAll but stops reading and returns ctx.Err() as soon as the given context is done.
+func (box *Box) GetAllCtx(ctx context.Context) (slice interface{}, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	const existingOnly = true
+	var cFn = func(visitorArg unsafe.Pointer) C.obx_err {
+		return C.obx_box_visit_all(box.cBox, dataVisitor, visitorArg)
+	}
+	return box.readUsingVisitor(ctx, existingOnly, 0, cFn)
 }
 
 func (box *Box) readManyObjects(existingOnly bool, cFn func() *C.OBX_bytes_array) (slice interface{}, err error) {
@@ -565,7 +874,7 @@ func (box *Box) readManyObjects(existingOnly bool, cFn func() *C.OBX_bytes_array
 				continue
 			}
 
-			object, err := binding.Load(box.ObjectBox, bytesData)
+			object, err := box.entity.load(box.ObjectBox, bytesData)
 			if err != nil {
 				return err
 			}
@@ -582,10 +891,23 @@ func (box *Box) readManyObjects(existingOnly bool, cFn func() *C.OBX_bytes_array
 }
 
 // this is a utility function to fetch objects using an obx_data_visitor
-func (box *Box) readUsingVisitor(existingOnly bool, cFn func(visitorArg unsafe.Pointer) C.obx_err) (slice interface{}, err error) {
+// readUsingVisitor collects objects passed to the visitor by cFn; the visit is stopped early if ctx is done.
+// Fails with ErrMaxResultsExceeded if maxResults (unless 0) would be exceeded.
+func (box *Box) readUsingVisitor(ctx context.Context, existingOnly bool, maxResults uint64, cFn func(visitorArg unsafe.Pointer) C.obx_err) (slice interface{}, err error) {
 	var binding = box.entity.binding
+	var count uint64
 	var visitor uint32
 	visitor, err = dataVisitorRegister(func(bytes []byte) bool {
+		if err2 := ctx.Err(); err2 != nil {
+			err = err2
+			return false
+		}
+
+		if count++; maxResults > 0 && count > maxResults {
+			err = ErrMaxResultsExceeded
+			return false
+		}
+
 		// may be nil if an object on this index was not found (can happen with GetMany)
 		if bytes == nil {
 			if !existingOnly {
@@ -594,7 +916,7 @@ func (box *Box) readUsingVisitor(existingOnly bool, cFn func(visitorArg unsafe.P
 			return true
 		}
 
-		object, err2 := binding.Load(box.ObjectBox, bytes)
+		object, err2 := box.entity.load(box.ObjectBox, bytes)
 		if err2 != nil {
 			err = err2
 			return false
@@ -628,7 +950,9 @@ func (box *Box) readUsingVisitor(existingOnly bool, cFn func(visitorArg unsafe.P
 // Contains checks whether an object with the given ID is stored.
 func (box *Box) Contains(id uint64) (bool, error) {
 	var cResult C.bool
-	if err := cCall(func() C.obx_err { return C.obx_box_contains(box.cBox, C.obx_id(id), &cResult) }); err != nil {
+	if err := box.ObjectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_box_contains(box.cBox, C.obx_id(id), &cResult) })
+	}); err != nil {
 		return false, err
 	}
 	return bool(cResult), nil
@@ -642,21 +966,64 @@ func (box *Box) ContainsIds(ids ...uint64) (bool, error) {
 	}
 
 	var cResult C.bool
-	err = cCall(func() C.obx_err {
-		return C.obx_box_contains_many(box.cBox, cIds.cArray, &cResult)
+	err = box.ObjectBox.guard(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_box_contains_many(box.cBox, cIds.cArray, &cResult)
+		})
 	})
 	return bool(cResult), err
 }
 
 // RelationIds returns IDs of all target objects related to the given source object ID
-func (box *Box) RelationIds(relation *RelationToMany, sourceId uint64) ([]uint64, error) {
+func (box *Box) RelationIds(relation *RelationToMany, sourceId uint64) (ids []uint64, err error) {
 	targetBox, err := box.ObjectBox.box(relation.Target.Id)
 	if err != nil {
 		return nil, err
 	}
-	return cGetIds(func() *C.OBX_id_array {
-		return C.obx_box_rel_get_ids(targetBox.cBox, C.obx_schema_id(relation.Id), C.obx_id(sourceId))
+
+	err = box.ObjectBox.guard(func() error {
+		ids, err = cGetIds(func() *C.OBX_id_array {
+			return C.obx_box_rel_get_ids(targetBox.cBox, C.obx_schema_id(relation.Id), C.obx_id(sourceId))
+		})
+		return err
 	})
+	return ids, err
+}
+
+// BacklinkIds returns IDs of the objects in this box whose to-one relation points to the given target object ID,
+// e.g. the orders of a customer: BoxForOrder(ob).BacklinkIds(Order_.Customer, customerId).
+// No redundant list of IDs needs to be stored on the target object.
+func (box *Box) BacklinkIds(relation *RelationToOne, targetId uint64) (ids []uint64, err error) {
+	if relation == nil || relation.Property == nil || relation.Property.Entity == nil {
+		return nil, errors.New("relation is not defined")
+	} else if relation.Property.Entity.Id != box.entity.id {
+		return nil, fmt.Errorf("relation property %d doesn't belong to entity %s", relation.Property.Id, box.entity.name)
+	}
+
+	err = box.ObjectBox.guard(func() error {
+		ids, err = cGetIds(func() *C.OBX_id_array {
+			return C.obx_box_get_backlink_ids(box.cBox, C.obx_schema_id(relation.Property.Id), C.obx_id(targetId))
+		})
+		return err
+	})
+	return ids, err
+}
+
+// GetBacklinks returns the objects in this box whose to-one relation points to the given target object ID,
+// see BacklinkIds(). The result is a slice of the objects in this box, like in GetMany().
+func (box *Box) GetBacklinks(relation *RelationToOne, targetId uint64) (slice interface{}, err error) {
+	err = box.ObjectBox.RunInReadTx(func() error {
+		ids, err := box.BacklinkIds(relation, targetId)
+		if err != nil {
+			return err
+		}
+		slice, err = box.GetManyExisting(ids...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slice, nil
 }
 
 // RelationReplace replaces all targets for a given source in a standalone many-to-many relation
@@ -745,14 +1112,28 @@ func (box *Box) RelationReplace(relation *RelationToMany, sourceId uint64, sourc
 
 // RelationPut creates a relation between the given source & target objects
 func (box *Box) RelationPut(relation *RelationToMany, sourceId, targetId uint64) error {
-	return cCall(func() C.obx_err {
-		return C.obx_box_rel_put(box.cBox, C.obx_schema_id(relation.Id), C.obx_id(sourceId), C.obx_id(targetId))
+	return box.ObjectBox.guard(func() error {
+		if err := box.ObjectBox.writeQueue.acquire(); err != nil {
+			return err
+		}
+		defer box.ObjectBox.writeQueue.release()
+
+		return cCall(func() C.obx_err {
+			return C.obx_box_rel_put(box.cBox, C.obx_schema_id(relation.Id), C.obx_id(sourceId), C.obx_id(targetId))
+		})
 	})
 }
 
 // RelationRemove removes a relation between the given source & target objects
 func (box *Box) RelationRemove(relation *RelationToMany, sourceId, targetId uint64) error {
-	return cCall(func() C.obx_err {
-		return C.obx_box_rel_remove(box.cBox, C.obx_schema_id(relation.Id), C.obx_id(sourceId), C.obx_id(targetId))
+	return box.ObjectBox.guard(func() error {
+		if err := box.ObjectBox.writeQueue.acquire(); err != nil {
+			return err
+		}
+		defer box.ObjectBox.writeQueue.release()
+
+		return cCall(func() C.obx_err {
+			return C.obx_box_rel_remove(box.cBox, C.obx_schema_id(relation.Id), C.obx_id(sourceId), C.obx_id(targetId))
+		})
 	})
 }
@@ -23,11 +23,20 @@ package objectbox
 import "C"
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
+	"strings"
+	"time"
 	"unsafe"
 )
 
+// defaultDirectory is the database directory used by the ObjectBox C library if none is configured
+const defaultDirectory = "objectbox"
+
+// inMemoryPrefix is the directory prefix recognized by the ObjectBox C library for in-memory databases
+const inMemoryPrefix = "memory:"
+
 // Builder provides tools to fully configure and construct ObjectBox
 type Builder struct {
 	model *Model
@@ -35,42 +44,86 @@ type Builder struct {
 
 	// these options are used when creating the underlying store using the C-api calls
 	// pointers are used to distinguish whether a value is present or not
-	directory   *string
-	maxSizeInKb *uint64
-	maxReaders  *uint
+	directory *string
+	readOnly  *bool
+	storeOptions
+
+	usePreviousCommit *bool
+
+	validateOnOpen *validateOnOpen
+
+	writeQueue *writeQueueOptions
+
+	// migrations are run after the store is opened (see MigrateTo), storing the version in schemaVersion
+	migrations    []migrationStep
+	schemaVersion *PropertyInt64
+
+	// softDelete holds the "deleted at" property per entity ID (see SoftDelete)
+	softDelete map[TypeId]*PropertyInt64
+
+	noReaderThreadLocals *bool
+
+	asyncMaxQueueLength  *uint
+	asyncMaxTxOperations *uint
+	asyncMaxTxDuration   *time.Duration
+
+	// lockTimeout is how long to wait for another store using the same directory to be closed (see WaitForLock)
+	lockTimeout time.Duration
+
+	// backupFile is restored before the store is opened (see FromBackup)
+	backupFile *string
+
+	// encryptionKey is kept separately because it's only checked, not passed to the C-API (see EncryptionKey)
+	encryptionKey []byte
 
 	// these options are passed-through to the created ObjectBox struct
 	options
 }
 
-// NewBuilder creates a new ObjectBox instance builder object
-func NewBuilder() *Builder {
-	var version = VersionLib()
-	if version.LessThan(VersionLibMin()) {
-		panic("The loaded ObjectBox C library is too old for this build of ObjectBox Go.\n" +
-			"Found version " + version.String() + ", but at least " + VersionLibMin().String() + " is required.\n" +
-			"Please see https://github.com/objectbox/objectbox-go on how to upgrade.\n" +
-			"Or, check https://github.com/objectbox/objectbox-c for the C library.")
-	} else if version.LessThan(VersionLibMinRecommended()) {
-		println("Note: the loaded ObjectBox C library should be updated.\n" +
-			"      Found ObjectBox version " + version.String() + ", but the minimum recommended version is " +
-			VersionLibMinRecommended().String() + ".")
-	}
+// storeOptions are kept by the ObjectBox struct to open other stores with the same options, e.g. by Compact()
+type storeOptions struct {
+	maxSizeInKb *uint64
+	maxReaders  *uint
+	fileMode    *uint
+}
 
-	return &Builder{
+// NewBuilder creates a new ObjectBox instance builder object.
+// If the loaded ObjectBox C library is incompatible, BuildOrError() fails with an IncompatibleCoreVersionError.
+func NewBuilder() *Builder {
+	var builder = &Builder{
 		options: options{
 			// defaults
 			asyncTimeout: 1000, // 1s ; TODO make this 0 to use core default?
 		},
 	}
+
+	var version = VersionLib()
+	if err := checkLibVersion(version); err != nil {
+		builder.Error = err
+	} else if version.LessThan(VersionLibMinRecommended()) {
+		defaultLogger().Log(LogLevelWarn, "the loaded ObjectBox C library should be updated",
+			"found", version.String(), "recommended", VersionLibMinRecommended().String())
+	}
+
+	return builder
 }
 
-// Directory configures the path where the database is stored
+// Directory configures the path where the database is stored.
+// The path is passed to the native library as UTF-8, which takes care of converting it for the OS, e.g. to UTF-16
+// on Windows; both slashes and backslashes can be used as separators on Windows.
 func (builder *Builder) Directory(path string) *Builder {
 	builder.directory = &path
 	return builder
 }
 
+// InMemory configures the database to be kept in memory only, without creating any files on disk.
+// The given name identifies the store, i.e. stores opened with the same name share the same data while they're open.
+// This is the same as calling Directory("memory:" + name).
+// Note: in-memory databases require ObjectBox C library v0.18.0 or newer.
+func (builder *Builder) InMemory(name string) *Builder {
+	return builder.Directory(inMemoryPrefix + name)
+}
+
 // MaxSizeInKb defines maximum size the database can take on disk (default: 1 GByte).
 func (builder *Builder) MaxSizeInKb(maxSizeInKb uint64) *Builder {
 	builder.maxSizeInKb = &maxSizeInKb
@@ -84,6 +137,128 @@ func (builder *Builder) MaxReaders(maxReaders uint) *Builder {
 	return builder
 }
 
+// FileMode defines the unix-style file mode (permissions) used when creating database files (default: 0644).
+func (builder *Builder) FileMode(fileMode uint) *Builder {
+	builder.fileMode = &fileMode
+	return builder
+}
+
+// ReadOnly opens the database in read-only mode: no schema updates and no write transactions are allowed.
+// This is useful e.g. to inspect a copy (snapshot) of a database without risking any modifications.
+func (builder *Builder) ReadOnly(readOnly bool) *Builder {
+	builder.readOnly = &readOnly
+	return builder
+}
+
+// UsePreviousCommit opens the database ignoring the latest committed transaction, i.e. with the previous snapshot.
+// This is an advanced option meant for recovery, e.g. to get back data removed by the last transaction; it should
+// be used together with ReadOnly(true) (and ideally on a copy of the database files) to make sure no data is lost.
+func (builder *Builder) UsePreviousCommit(value bool) *Builder {
+	builder.usePreviousCommit = &value
+	return builder
+}
+
+// validateOnOpen holds the options of Builder.ValidateOnOpen()
+type validateOnOpen struct {
+	pageLimit uint
+	leafLevel bool
+}
+
+// ValidateOnOpen checks the database pages when opening the store, e.g. for deployments on unreliable flash storage.
+// pageLimit limits the number of checked pages (0 = the native default), leafLevel enables checking leaf pages too.
+// If validation fails, BuildOrError() returns an error matching ErrFileCorrupt or ErrFilePagesCorrupt.
+// Note: ObjectBox builds upon ACID storage, which guarantees consistency given that the file system works correctly.
+func (builder *Builder) ValidateOnOpen(pageLimit uint, leafLevel bool) *Builder {
+	builder.validateOnOpen = &validateOnOpen{pageLimit: pageLimit, leafLevel: leafLevel}
+	return builder
+}
+
+// NoReaderThreadLocals disables caching of "readers" (used by read transactions) per OS thread.
+// By default, the native library keeps readers for each thread, which makes repeated reads cheap but holds on to
+// a reader slot for as long as the thread lives. Consider this option (experimental in the native library) if you
+// hit ErrMaxReadersExceeded with many long-lived threads, or increase MaxReaders() instead.
+func (builder *Builder) NoReaderThreadLocals(value bool) *Builder {
+	builder.noReaderThreadLocals = &value
+	return builder
+}
+
+// AsyncMaxQueueLength limits the number of async operations (see AsyncBox) waiting to be executed; once the queue
+// is full, submitting another operation waits for a free slot (default: defined by the native library).
+func (builder *Builder) AsyncMaxQueueLength(length uint) *Builder {
+	builder.asyncMaxQueueLength = &length
+	return builder
+}
+
+// AsyncMaxTxOperations limits the number of async operations executed in a single write transaction.
+// Higher values make bulk async puts faster, at the cost of blocking other writers for longer.
+func (builder *Builder) AsyncMaxTxOperations(count uint) *Builder {
+	builder.asyncMaxTxOperations = &count
+	return builder
+}
+
+// AsyncMaxTxDuration limits how long a single write transaction executing async operations may take before it's
+// committed and a new one is started. Similar to AsyncMaxTxOperations, this trades throughput for latency.
+func (builder *Builder) AsyncMaxTxDuration(duration time.Duration) *Builder {
+	builder.asyncMaxTxDuration = &duration
+	return builder
+}
+
+// WaitForLock configures how long BuildOrError() waits for another store using the same directory to be closed
+// (default: 0, i.e. fail immediately with a StoreAlreadyOpenError). Only stores open in this process are waited for.
+func (builder *Builder) WaitForLock(timeout time.Duration) *Builder {
+	builder.lockTimeout = timeout
+	return builder
+}
+
+// FromBackup configures a database file created by ObjectBox.Backup() to initialize the store with.
+// The backup is only restored if there's no database in the configured directory yet, so it's safe to always set it,
+// e.g. to ship a pre-populated database with an application.
+func (builder *Builder) FromBackup(path string) *Builder {
+	builder.backupFile = &path
+	return builder
+}
+
+// EncryptionKey configures at-rest encryption of the database files using the given key.
+// Note: the ObjectBox C library this version of ObjectBox Go is built against doesn't provide encryption,
+// so BuildOrError() fails with an error if a key is set. The option is kept to fail loudly instead of silently
+// storing data unencrypted.
+func (builder *Builder) EncryptionKey(key []byte) *Builder {
+	builder.encryptionKey = append([]byte{}, key...)
+	return builder
+}
+
+// WriteQueue makes writers take turns in a queue on the Go side, in the order they arrived, so that latency under
+// write contention is predictable. Applies to write transactions and to writes outside of an explicit transaction,
+// except async ones. maxWaiting limits the number of waiting writers (0 = no limit), further writes fail with
+// ErrWriteQueueFull. timeout limits how long a writer waits for its turn (0 = no limit), then it fails with
+// ErrWriteQueueTimeout. Use ObjectBox.WriteQueueStats() to monitor the queue (default: no queue).
+func (builder *Builder) WriteQueue(maxWaiting int, timeout time.Duration) *Builder {
+	builder.writeQueue = &writeQueueOptions{maxWaiting: maxWaiting, timeout: timeout}
+	return builder
+}
+
+// RetryPolicy configures retrying of transactions failing to begin with a transient error, such as
+// ErrMaxReadersExceeded under heavy concurrency (default: no retries).
+// If all attempts fail, the error of the last one is returned.
+func (builder *Builder) RetryPolicy(policy RetryPolicy) *Builder {
+	builder.retryPolicy = policy
+	return builder
+}
+
+// Observer configures a function receiving an event for each finished put, get, query, remove and transaction,
+// e.g. to collect metrics like operation counts and latencies (default: none).
+func (builder *Builder) Observer(observer OperationObserver) *Builder {
+	builder.observer = observer
+	return builder
+}
+
+// Logger configures where this store logs internal errors which can't be returned to the caller,
+// e.g. from finalizers (default: the package-level logger, see SetLogger).
+func (builder *Builder) Logger(logger Logger) *Builder {
+	builder.logger = logger
+	return builder
+}
+
 // asyncTimeoutTBD configures the default enqueue timeout for async operations (default is 1 second).
 // See Box.PutAsync method doc for more information.
 // TODO: implement this option in core and use it
@@ -128,6 +303,46 @@ func (builder *Builder) BuildOrError() (*ObjectBox, error) {
 
 	if builder.model == nil {
 		return nil, fmt.Errorf("model is not defined")
+	} else if builder.model.cModel == nil {
+		// the native model is consumed when opening a store and entities keep a reference to their store
+		return nil, fmt.Errorf("model has already been used to open a store; create a new one for each store")
+	}
+
+	for _, deletedAt := range builder.softDelete {
+		if err := checkSoftDeleteProperty(builder.model, deletedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if builder.directory != nil && strings.HasPrefix(*builder.directory, inMemoryPrefix) && !InMemoryIsAvailable() {
+		return nil, fmt.Errorf("in-memory database %q requested but the loaded ObjectBox C library version %v "+
+			"doesn't support it; at least %v is required", *builder.directory, VersionLib(), versionLibInMemory)
+	}
+
+	if builder.encryptionKey != nil {
+		return nil, fmt.Errorf("database encryption requested but the loaded ObjectBox C library version %v "+
+			"doesn't support it", VersionLib())
+	}
+
+	var directory = defaultDirectory
+	if builder.directory != nil {
+		directory = *builder.directory
+	} else if runtime.GOOS == "android" {
+		// the working directory of an Android app is the read-only root, so the relative default can't work
+		return nil, errors.New("the database directory must be configured on Android, e.g. using " +
+			"Context.getFilesDir() + \"/objectbox\" passed from the app")
+	}
+
+	if err := waitForStoreClosed(directory, builder.lockTimeout); err != nil {
+		return nil, err
+	}
+
+	if builder.backupFile != nil {
+		if strings.HasPrefix(directory, inMemoryPrefix) {
+			return nil, fmt.Errorf("can't restore a backup to an in-memory database %q", directory)
+		} else if err := restoreBackup(*builder.backupFile, directory); err != nil {
+			return nil, err
+		}
 	}
 
 	// for native calls/createError()
@@ -156,12 +371,51 @@ func (builder *Builder) BuildOrError() (*ObjectBox, error) {
 		C.obx_opt_max_readers(cOptions, C.uint(*builder.maxReaders))
 	}
 
+	if builder.fileMode != nil {
+		C.obx_opt_file_mode(cOptions, C.uint(*builder.fileMode))
+	}
+
+	if builder.readOnly != nil {
+		C.obx_opt_read_only(cOptions, C.bool(*builder.readOnly))
+	}
+
+	if builder.usePreviousCommit != nil {
+		C.obx_opt_use_previous_commit(cOptions, C.bool(*builder.usePreviousCommit))
+	}
+
+	if builder.validateOnOpen != nil {
+		C.obx_opt_validate_on_open(cOptions, C.size_t(builder.validateOnOpen.pageLimit), C.bool(builder.validateOnOpen.leafLevel))
+	}
+
+	if builder.noReaderThreadLocals != nil {
+		C.obx_opt_no_reader_thread_locals(cOptions, C.bool(*builder.noReaderThreadLocals))
+	}
+
+	if builder.asyncMaxQueueLength != nil {
+		C.obx_opt_async_max_queue_length(cOptions, C.size_t(*builder.asyncMaxQueueLength))
+	}
+
+	if builder.asyncMaxTxOperations != nil {
+		C.obx_opt_async_max_in_tx_operations(cOptions, C.uint32_t(*builder.asyncMaxTxOperations))
+	}
+
+	if builder.asyncMaxTxDuration != nil {
+		C.obx_opt_async_max_in_tx_duration(cOptions, C.uint32_t(*builder.asyncMaxTxDuration/time.Microsecond))
+	}
+
+	// the native model is consumed by obx_opt_model(), even if it fails
 	C.obx_opt_model(cOptions, builder.model.cModel)
+	builder.model.cModel = nil
 
 	// cOptions is consumed by obx_store_open() so no need to free it
 	cStore := C.obx_store_open(cOptions)
 	if cStore == nil {
-		return nil, createError()
+		var err = createError()
+		// another store may have been opened in the meantime, after waitForStoreClosed()
+		if storeIsOpen(directory) {
+			err = &StoreAlreadyOpenError{Directory: directory}
+		}
+		return nil, err
 	}
 
 	ob := &ObjectBox{
@@ -170,10 +424,42 @@ func (builder *Builder) BuildOrError() (*ObjectBox, error) {
 		entitiesByName: builder.model.entitiesByName,
 		boxes:          make(map[TypeId]*Box, len(builder.model.entitiesById)),
 		options:        builder.options,
+		directory:      directory,
+		storeOptions:   builder.storeOptions,
+		softDelete:     builder.softDelete,
+		activity:       newActivity(),
+	}
+
+	if builder.writeQueue != nil {
+		ob.writeQueue = newWriteQueue(*builder.writeQueue)
 	}
 
 	for _, entity := range builder.model.entitiesById {
 		entity.objectBox = ob
 	}
+
+	if err := ob.migrate(builder.schemaVersion, builder.migrations, builder.readOnly != nil && *builder.readOnly); err != nil {
+		ob.Close()
+		return nil, err
+	}
 	return ob, nil
 }
+
+// storeIsOpen checks whether there's an open store using the given directory in this process
+func storeIsOpen(directory string) bool {
+	var cDir = C.CString(directory)
+	defer C.free(unsafe.Pointer(cDir))
+	return bool(C.obx_store_is_open(cDir))
+}
+
+// waitForStoreClosed fails with StoreAlreadyOpenError if a store using the given directory is still open after timeout
+func waitForStoreClosed(directory string, timeout time.Duration) error {
+	var deadline = time.Now().Add(timeout)
+	for storeIsOpen(directory) {
+		if !time.Now().Before(deadline) {
+			return &StoreAlreadyOpenError{Directory: directory}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
@@ -161,7 +161,7 @@ func cCallbackLookup(id C.uintptr_t) cCallable {
 	fn, found := cCallbackMap[cCallbackId(id)]
 	if !found {
 		// this might happen in extraordinary circumstances, e.g. during shutdown if there are still some sync listeners
-		fmt.Println(fmt.Errorf("invalid C-API callback ID %d", id))
+		defaultLogger().Log(LogLevelWarn, "invalid C-API callback ID", "id", uint64(id))
 		return nil
 	}
 
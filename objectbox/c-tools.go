@@ -314,5 +314,9 @@ func createError() error {
 	if msg == nil {
 		return errors.New("no error info available; please report")
 	}
-	return errors.New(C.GoString(msg))
+	return &Error{
+		code:          int(C.obx_last_error_code()),
+		secondaryCode: int(C.obx_last_error_secondary()),
+		message:       C.GoString(msg),
+	}
 }
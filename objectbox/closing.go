@@ -0,0 +1,102 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdint.h>
+
+// ID of the store whose transaction is running on the current OS thread, 0 if there's none
+static __thread uint64_t goTxStoreId = 0;
+
+static uint64_t goTxStoreIdSwap(uint64_t id) {
+	uint64_t previous = goTxStoreId;
+	goTxStoreId = id;
+	return previous;
+}
+
+static uint64_t goTxStoreIdGet() { return goTxStoreId; }
+*/
+import "C"
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrStoreClosed is returned by operations on a store (and its boxes and queries) which was closed or is closing
+var ErrStoreClosed = errors.New("store is closed")
+
+// activity tracks operations in progress so that closing the store waits for them to finish
+type activity struct {
+	id      uint64 // identifies the store in goTxStoreId; unique in the process
+	mutex   sync.Mutex
+	active  int
+	closing bool
+	idle    chan struct{} // closed once there are no active operations after closing has started
+}
+
+var lastActivityId uint64
+
+func newActivity() activity {
+	return activity{id: atomic.AddUint64(&lastActivityId, 1)}
+}
+
+// guard runs fn as an operation in progress: Close() waits for it to finish. Once closing has started, fn isn't called
+// and ErrStoreClosed is returned instead, unless it's called inside a transaction of this store (on the same thread),
+// which Close() waits for anyway.
+func (ob *ObjectBox) guard(fn func() error) error {
+	if err := ob.enter(); err != nil {
+		return err
+	}
+	defer ob.leave()
+	return fn()
+}
+
+// enter registers an operation in progress; it must be followed by leave() if (and only if) it succeeds
+func (ob *ObjectBox) enter() error {
+	ob.activity.mutex.Lock()
+	defer ob.activity.mutex.Unlock()
+
+	if ob.activity.closing && uint64(C.goTxStoreIdGet()) != ob.activity.id {
+		return ErrStoreClosed
+	}
+	ob.activity.active++
+	return nil
+}
+
+// leave marks the operation registered by enter() as finished
+func (ob *ObjectBox) leave() {
+	ob.activity.mutex.Lock()
+	defer ob.activity.mutex.Unlock()
+
+	ob.activity.active--
+	if ob.activity.active == 0 && ob.activity.idle != nil {
+		close(ob.activity.idle)
+	}
+}
+
+// enterTx marks the current OS thread, which must be locked, as running a transaction of this store.
+// Returns the previous mark to be restored by leaveTx().
+func (ob *ObjectBox) enterTx() uint64 {
+	return uint64(C.goTxStoreIdSwap(C.uint64_t(ob.activity.id)))
+}
+
+// leaveTx restores the mark replaced by enterTx(), before the OS thread is unlocked
+func leaveTx(previous uint64) {
+	C.goTxStoreIdSwap(C.uint64_t(previous))
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+// compactBatchSize is the number of objects read from the source store and written to the target store at once
+const compactBatchSize = 1000
+
+// Compact writes a compacted copy of the database to the given (new or empty) directory, while the store stays open.
+// Removing objects doesn't shrink the database file, the space is only reused for future writes. The copy contains
+// just the current data (all objects with their IDs and standalone relations), thus it's usually much smaller.
+// The model must describe the same entities as the one this store was opened with, i.e. pass a new instance created
+// by the generated ObjectBoxModel() function. The copy is opened with the same MaxSizeInKb, MaxReaders and FileMode
+// options as this store.
+// The objects are copied in batches, each read in its own read transaction and written in its own write transaction,
+// so the copy isn't a single snapshot: run it during a maintenance window, without concurrent writes.
+// To replace the original database, close the store and swap the directories.
+func (ob *ObjectBox) Compact(targetDirectory string, model *Model) (err error) {
+	if strings.HasPrefix(ob.directory, inMemoryPrefix) {
+		return fmt.Errorf("can't compact an in-memory database %q", ob.directory)
+	} else if _, err := os.Stat(filepath.Join(targetDirectory, dataFileName)); err == nil {
+		return fmt.Errorf("target directory %q already contains a database", targetDirectory)
+	}
+
+	var builder = NewBuilder().Directory(targetDirectory).Model(model)
+	builder.storeOptions = ob.storeOptions
+	target, err := builder.BuildOrError()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		target.Close()
+		if err != nil {
+			os.RemoveAll(targetDirectory)
+		}
+	}()
+
+	for name, entity := range ob.entitiesByName {
+		if targetEntity := target.entitiesByName[name]; targetEntity == nil || targetEntity.id != entity.id {
+			return fmt.Errorf("entity %s doesn't match the model of the compacted database", name)
+		}
+	}
+
+	for _, entity := range ob.entitiesById {
+		if err := ob.compactEntity(target, entity); err != nil {
+			return fmt.Errorf("can't compact entity %s: %s", entity.name, err)
+		}
+	}
+	return nil
+}
+
+// compactObject is a copy of a single object read from the source store, including its standalone relations
+type compactObject struct {
+	id        uint64
+	data      []byte
+	relations [][]uint64 // target IDs for each of entity.relations
+}
+
+// compactEntity copies all objects of the given entity, and their standalone relations, to the target store.
+// The objects are copied in batches, in the order of their IDs.
+func (ob *ObjectBox) compactEntity(target *ObjectBox, entity *entity) error {
+	source, err := ob.box(entity.id)
+	if err != nil {
+		return err
+	}
+
+	dest, err := target.box(entity.id)
+	if err != nil {
+		return err
+	}
+
+	// reads the next batch of objects, with IDs after the ones already copied
+	var lastIdAlias = Alias("lastId")
+	var afterLastId = &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(&BaseProperty{Id: entity.idProperty().id, Entity: &Entity{Id: entity.id}}, 0, false)
+		},
+	}
+	query, err := source.buildQuery([]Condition{afterLastId.As(lastIdAlias)})
+	if err != nil {
+		return err
+	}
+	defer query.Close()
+	if err = query.Limit(compactBatchSize).check(); err != nil {
+		return err
+	}
+
+	var lastId uint64
+	for {
+		if err := query.SetInt64Params(lastIdAlias, int64(lastId)); err != nil {
+			return err
+		}
+
+		batch, err := ob.compactRead(query, entity)
+		if err != nil {
+			return err
+		} else if len(batch) == 0 {
+			return nil
+		}
+
+		if err := target.RunInWriteTx(func() error {
+			for _, object := range batch {
+				if err := dest.putRaw(object.id, object.data); err != nil {
+					return err
+				}
+				for i, relation := range entity.relations {
+					for _, targetId := range object.relations[i] {
+						if err := dest.RelationPut(relation, object.id, targetId); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		lastId = batch[len(batch)-1].id
+	}
+}
+
+// compactRead reads the objects found by the query, including their standalone relations, in a single read transaction
+func (ob *ObjectBox) compactRead(query *Query, entity *entity) (batch []compactObject, err error) {
+	visitor, err := dataVisitorRegister(func(bytes []byte) bool {
+		var object = compactObject{data: append([]byte{}, bytes...)} // the data is only valid during the transaction
+		if object.id, err = entity.readId(bytes); err == nil {
+			batch = append(batch, object)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer dataVisitorUnregister(visitor)
+
+	// use another `error` variable as `err` may be set by the visitor callback above
+	var err2 = ob.RunInReadTx(func() error {
+		if err := cCall(func() C.obx_err {
+			return C.obx_query_visit(query.cQuery, dataVisitor, unsafe.Pointer(&visitor))
+		}); err != nil || len(entity.relations) == 0 {
+			return err
+		}
+
+		for i := range batch {
+			batch[i].relations = make([][]uint64, len(entity.relations))
+			for r, relation := range entity.relations {
+				if batch[i].relations[r], err = query.box.RelationIds(relation, batch[i].id); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	if err2 != nil {
+		return nil, err2
+	} else if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// ContentHash computes a hex-encoded SHA-256 hash over the given field values, e.g. to deduplicate objects.
+// Keep the hash in a string property (ideally with a hash index) and set it from a BeforePut hook, so it's maintained
+// on every Put:
+//
+//	func (doc *Document) BeforePut() (err error) {
+//		doc.ContentHash, err = objectbox.ContentHash(doc.Title, doc.Body, doc.Tags)
+//		return err
+//	}
+//
+// Supported are nil, bool, integer, float, string, []byte, []string and time.Time values (or pointers to them).
+// Each value is encoded with its type and length, thus e.g. ("ab", "c") and ("a", "bc") result in different hashes.
+func ContentHash(values ...interface{}) (string, error) {
+	var hash = sha256.New()
+	var buf [9]byte
+	var write = func(kind byte, data []byte) {
+		buf[0] = kind
+		binary.LittleEndian.PutUint64(buf[1:], uint64(len(data)))
+		hash.Write(buf[:])
+		hash.Write(data)
+	}
+	var number = func(kind byte, value uint64) {
+		var data [8]byte
+		binary.LittleEndian.PutUint64(data[:], value)
+		write(kind, data[:])
+	}
+
+	for i, value := range values {
+		var rv = reflect.ValueOf(value)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+
+		if !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+			write('0', nil)
+			continue
+		}
+
+		if t, ok := rv.Interface().(time.Time); ok {
+			number('t', uint64(t.UnixNano()))
+			continue
+		}
+
+		switch rv.Kind() {
+		case reflect.Bool:
+			if rv.Bool() {
+				number('b', 1)
+			} else {
+				number('b', 0)
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number('i', uint64(rv.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			number('u', rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			number('f', math.Float64bits(rv.Float()))
+		case reflect.String:
+			write('s', []byte(rv.String()))
+		case reflect.Slice:
+			if rv.Type().Elem().Kind() == reflect.Uint8 {
+				write('B', rv.Bytes())
+			} else if rv.Type().Elem().Kind() == reflect.String {
+				number('S', uint64(rv.Len()))
+				for j := 0; j < rv.Len(); j++ {
+					write('s', []byte(rv.Index(j).String()))
+				}
+			} else {
+				return "", fmt.Errorf("content hash value %d: unsupported type %s", i, rv.Type())
+			}
+		default:
+			return "", fmt.Errorf("content hash value %d: unsupported type %s", i, rv.Type())
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// FindByContentHash returns the first object with the given hash in the given property, or nil if there's none.
+// See ContentHash() for maintaining such a property.
+func (box *Box) FindByContentHash(property *PropertyString, hash string) (object interface{}, err error) {
+	query, err := box.QueryOrError(property.Equals(hash, true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+	return query.FindFirst()
+}
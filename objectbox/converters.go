@@ -17,6 +17,7 @@
 package objectbox
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -96,3 +97,27 @@ func TimeBinaryConvertToDatabaseValue(goValue time.Time) ([]byte, error) {
 	}
 	return bytes, err
 }
+
+// MapJsonConvertToEntityProperty uses encoding/json to decode a JSON string to a map; an empty string results in nil.
+// Use it with a string property to store loosely structured data, e.g. `objectbox:"type:string converter:..."`.
+func MapJsonConvertToEntityProperty(dbValue string) (goValue map[string]interface{}, err error) {
+	if dbValue == "" {
+		return nil, nil
+	}
+	if err = json.Unmarshal([]byte(dbValue), &goValue); err != nil {
+		err = fmt.Errorf("error unmarshalling map from JSON %v: %v", dbValue, err)
+	}
+	return goValue, err
+}
+
+// MapJsonConvertToDatabaseValue uses encoding/json to encode a map as a JSON string; a nil map results in "".
+func MapJsonConvertToDatabaseValue(goValue map[string]interface{}) (string, error) {
+	if goValue == nil {
+		return "", nil
+	}
+	bytes, err := json.Marshal(goValue)
+	if err != nil {
+		err = fmt.Errorf("error marshalling map %v to JSON: %v", goValue, err)
+	}
+	return string(bytes), err
+}
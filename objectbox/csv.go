@@ -0,0 +1,163 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// CSVOptions configures Query.ExportCSV
+type CSVOptions struct {
+	// Delimiter separates the values in a row; defaults to a comma if not set
+	Delimiter rune
+
+	// Header causes the first row to contain the property names
+	Header bool
+}
+
+// ExportCSV writes the given properties of all objects matching the query as CSV rows.
+// Values are read directly from the stored data, without constructing the Go objects (relations aren't resolved).
+// Nil values are written as empty strings, byte vectors are base64 encoded; string vectors and flex properties are not
+// supported and cause an error before anything is written.
+func (query *Query) ExportCSV(w io.Writer, options CSVOptions, props ...Property) error {
+	defer runtime.KeepAlive(query)
+
+	if err := query.check(); err != nil {
+		return err
+	}
+
+	if len(props) == 0 {
+		return fmt.Errorf("no properties given")
+	}
+
+	var columns = make([]*property, len(props))
+	for i, prop := range props {
+		if prop.entityId() != query.entity.id {
+			return fmt.Errorf("property from a different entity %d passed, expected %d", prop.entityId(), query.entity.id)
+		}
+		if columns[i] = query.entity.property(prop.propertyId()); columns[i] == nil {
+			return fmt.Errorf("property %d not found in entity %s", prop.propertyId(), query.entity.name)
+		} else if !columns[i].csvSupported() {
+			return fmt.Errorf("property %s: type %d is not supported in CSV export", columns[i].name,
+				columns[i].propertyType)
+		}
+	}
+
+	var writer = csv.NewWriter(w)
+	if options.Delimiter != 0 {
+		writer.Comma = options.Delimiter
+	}
+
+	var record = make([]string, len(columns))
+	if options.Header {
+		for i, column := range columns {
+			record[i] = column.name
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	var err2 = query.FindRaw(func(bytes []byte) bool {
+		var table = &flatbuffers.Table{
+			Bytes: bytes,
+			Pos:   flatbuffers.GetUOffsetT(bytes),
+		}
+		for i, column := range columns {
+			if record[i], err = column.csvValue(table); err != nil {
+				return false
+			}
+		}
+		if err = writer.Write(record); err != nil {
+			return false
+		}
+		return true
+	})
+
+	if err2 != nil {
+		return err2
+	} else if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvSupported checks whether values of the property can be written by csvValue()
+func (prop *property) csvSupported() bool {
+	switch prop.propertyType {
+	case C.OBXPropertyType_Bool, C.OBXPropertyType_Byte, C.OBXPropertyType_Char, C.OBXPropertyType_Short,
+		C.OBXPropertyType_Int, C.OBXPropertyType_Long, C.OBXPropertyType_Date, C.OBXPropertyType_DateNano,
+		C.OBXPropertyType_Relation, C.OBXPropertyType_Float, C.OBXPropertyType_Double, C.OBXPropertyType_String,
+		C.OBXPropertyType_ByteVector:
+		return true
+	}
+	return false
+}
+
+// csvValue reads the property value from the given FlatBuffers table and formats it as a string
+func (prop *property) csvValue(table *flatbuffers.Table) (string, error) {
+	value, err := prop.read(table)
+	if err != nil || value == nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v), nil
+	case uint64:
+		// read() returns the bit pattern, sign-extend it according to the property size unless it's unsigned
+		if prop.flags&C.OBXPropertyFlags_UNSIGNED != 0 || prop.flags&C.OBXPropertyFlags_ID != 0 ||
+			prop.propertyType == C.OBXPropertyType_Relation {
+			return strconv.FormatUint(v, 10), nil
+		}
+		switch prop.propertyType {
+		case C.OBXPropertyType_Byte, C.OBXPropertyType_Char:
+			return strconv.FormatInt(int64(int8(v)), 10), nil
+		case C.OBXPropertyType_Short:
+			return strconv.FormatInt(int64(int16(v)), 10), nil
+		case C.OBXPropertyType_Int:
+			return strconv.FormatInt(int64(int32(v)), 10), nil
+		}
+		return strconv.FormatInt(int64(v), 10), nil
+	case float64:
+		if prop.propertyType == C.OBXPropertyType_Float {
+			return strconv.FormatFloat(v, 'g', -1, 32), nil
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case string:
+		return v, nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	}
+	return "", fmt.Errorf("property %s: type %d is not supported in CSV export", prop.name, prop.propertyType)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// dedicatedEntity reads and writes objects of an entity dedicated to a single purpose, e.g. the outbox messages,
+// see "Dedicated entities" in the package documentation. Objects are built from the given property values directly,
+// without the generated binding; properties without a value are left empty.
+type dedicatedEntity struct {
+	box    *Box
+	id     *property
+	lastId TypeId // the highest property ID, see entity.build()
+}
+
+func (ob *ObjectBox) dedicatedEntity(entityId TypeId) (*dedicatedEntity, error) {
+	var entity = ob.entitiesById[entityId]
+	if entity == nil {
+		return nil, fmt.Errorf("entity %d is not part of the model", entityId)
+	}
+
+	box, err := ob.box(entity.id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dedicated = &dedicatedEntity{box: box, id: entity.idProperty()}
+	if dedicated.id == nil {
+		return nil, fmt.Errorf("entity %s has no ID property", entity.name)
+	}
+	for _, prop := range entity.properties {
+		if prop.id > dedicated.lastId {
+			dedicated.lastId = prop.id
+		}
+	}
+	return dedicated, nil
+}
+
+// property returns the entity's property with the given ID, or nil if the property belongs to another entity
+func (dedicated *dedicatedEntity) property(base *BaseProperty) *property {
+	if base.entityId() != dedicated.box.entity.id {
+		return nil
+	}
+	return dedicated.box.entity.property(base.propertyId())
+}
+
+// put stores an object with the given values (in the representation produced by patchValue()) and returns its ID.
+// A new ID is assigned if id is 0.
+func (dedicated *dedicatedEntity) put(id uint64, values map[TypeId]interface{}, sizeHint int,
+	putMode C.OBXPutMode) (uint64, error) {
+	if id == 0 {
+		var err error
+		if id, err = dedicated.box.idForPut(0); err != nil {
+			return 0, err
+		}
+	}
+
+	values[dedicated.id.id] = id
+	var data = dedicated.box.entity.build(values, dedicated.lastId, sizeHint)
+
+	return id, cCall(func() C.obx_err {
+		return C.obx_box_put5(dedicated.box.cBox, C.obx_id(id), unsafe.Pointer(&data[0]), C.size_t(len(data)), putMode)
+	})
+}
+
+// visit calls fn for the objects matching the query, or for all objects if query is nil, until fn returns false or an
+// error. The table is only valid during the call. Must be called inside a transaction.
+func (dedicated *dedicatedEntity) visit(query *Query, fn func(table *flatbuffers.Table) (bool, error)) error {
+	var err error
+	visitor, err2 := dataVisitorRegister(func(bytes []byte) bool {
+		var more bool
+		more, err = fn(rawTable(bytes))
+		return more && err == nil
+	})
+	if err2 != nil {
+		return err2
+	}
+	defer dataVisitorUnregister(visitor)
+
+	// use another `error` variable as `err` may be set by the visitor callback above
+	err2 = cCall(func() C.obx_err {
+		if query != nil {
+			return C.obx_query_visit(query.cQuery, dataVisitor, unsafe.Pointer(&visitor))
+		}
+		return C.obx_box_visit_all(dedicated.box.cBox, dataVisitor, unsafe.Pointer(&visitor))
+	})
+
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
@@ -36,6 +36,13 @@ they are discarded here to keep the example concise)
 	// Delete
 	box.Remove(person)
 
+Dedicated entities
+
+Some features keep their data in an entity of your model dedicated to that purpose: Outbox (the messages), Sequence
+(the counters) and Builder.SchemaVersionProperty() (the schema version used by migrations). Define the entity as any
+other, i.e. with an ID and the properties the feature requires, run the generator and pass the generated properties to
+the feature. ObjectBox then reads and writes the objects of such an entity itself; don't store other data in it.
+Properties the feature doesn't know about are left empty.
 
 To learn more, see https://golang.objectbox.io/
 */
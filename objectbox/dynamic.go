@@ -0,0 +1,488 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-generator/cmd/objectbox-gogen"
+)
+
+// PropertyType is a stored property type, as used by DynamicProperty
+type PropertyType int
+
+// Property types usable in dynamic entities, see DynamicProperty
+const (
+	PropertyTypeBool         PropertyType = C.OBXPropertyType_Bool
+	PropertyTypeByte         PropertyType = C.OBXPropertyType_Byte
+	PropertyTypeShort        PropertyType = C.OBXPropertyType_Short
+	PropertyTypeInt          PropertyType = C.OBXPropertyType_Int
+	PropertyTypeLong         PropertyType = C.OBXPropertyType_Long
+	PropertyTypeFloat        PropertyType = C.OBXPropertyType_Float
+	PropertyTypeDouble       PropertyType = C.OBXPropertyType_Double
+	PropertyTypeString       PropertyType = C.OBXPropertyType_String
+	PropertyTypeDate         PropertyType = C.OBXPropertyType_Date
+	PropertyTypeDateNano     PropertyType = C.OBXPropertyType_DateNano
+	PropertyTypeByteVector   PropertyType = C.OBXPropertyType_ByteVector
+	PropertyTypeStringVector PropertyType = C.OBXPropertyType_StringVector
+)
+
+// DynamicObject is an object of a dynamic entity: property values by the property name.
+// Values read from the database have the following types, depending on the property type:
+// bool, uint8 (Byte), int16 (Short), int32 (Int), int64 (Long), float32 (Float), float64 (Double), string,
+// time.Time (Date, DateNano), []byte and []string. The ID is an uint64.
+// When putting objects, any Go integer type is accepted for integer properties, time.Time or an integer for dates.
+// Missing (or nil) values aren't stored.
+type DynamicObject map[string]interface{}
+
+// DynamicEntity defines an entity registered at runtime, without generated code, see Model.RegisterDynamicEntity().
+// IDs and UIDs must be unique and stable across runs, like the ones assigned by the generator in objectbox-model.json.
+type DynamicEntity struct {
+	Name string
+	Id   TypeId
+	Uid  uint64
+
+	// IdProperty is the name of the ID property, stored as an uint64; "Id" if empty
+	IdProperty    string
+	IdPropertyId  TypeId
+	IdPropertyUid uint64
+
+	Properties []DynamicProperty
+}
+
+// DynamicProperty defines a property of a DynamicEntity
+type DynamicProperty struct {
+	Name string
+	Type PropertyType
+	Id   TypeId
+	Uid  uint64
+
+	// Index creates a value index on the property if IndexId is not zero
+	IndexId  TypeId
+	IndexUid uint64
+}
+
+// RegisterDynamicEntity adds an entity defined at runtime to the model, e.g. for plugins which can't run the code
+// generator. Objects of the entity are DynamicObject values; use ObjectBox.DynamicBox() to work with them.
+// As with generated code, LastEntityId(), LastIndexId() etc. must cover the IDs used by dynamic entities.
+func (model *Model) RegisterDynamicEntity(definition DynamicEntity) {
+	if model.Error != nil {
+		return
+	}
+
+	if definition.IdProperty == "" {
+		definition.IdProperty = "Id"
+	}
+
+	var names = map[string]bool{definition.IdProperty: true}
+	for _, prop := range definition.Properties {
+		if names[prop.Name] {
+			model.Error = fmt.Errorf("dynamic entity %s: duplicate property %s", definition.Name, prop.Name)
+			return
+		}
+		names[prop.Name] = true
+
+		switch prop.Type {
+		case PropertyTypeBool, PropertyTypeByte, PropertyTypeShort, PropertyTypeInt, PropertyTypeLong,
+			PropertyTypeFloat, PropertyTypeDouble, PropertyTypeString, PropertyTypeDate, PropertyTypeDateNano,
+			PropertyTypeByteVector, PropertyTypeStringVector:
+		default:
+			model.Error = fmt.Errorf("dynamic entity %s: property %s has an unsupported type %d",
+				definition.Name, prop.Name, prop.Type)
+			return
+		}
+	}
+
+	model.RegisterBinding(&dynamicBinding{definition: definition})
+}
+
+// dynamicBinding implements ObjectBinding for DynamicObject values
+type dynamicBinding struct {
+	definition DynamicEntity
+	entity     *entity
+	idProperty *property
+	lastId     TypeId
+}
+
+func (binding *dynamicBinding) AddToModel(model *Model) {
+	var def = binding.definition
+	model.Entity(def.Name, def.Id, def.Uid)
+	binding.entity = model.currentEntity
+
+	model.Property(def.IdProperty, C.OBXPropertyType_Long, def.IdPropertyId, def.IdPropertyUid)
+	model.PropertyFlags(C.OBXPropertyFlags_ID)
+	binding.lastId = def.IdPropertyId
+	var lastUid = def.IdPropertyUid
+
+	for _, prop := range def.Properties {
+		model.Property(prop.Name, int(prop.Type), prop.Id, prop.Uid)
+		if prop.IndexId != 0 {
+			model.PropertyFlags(C.OBXPropertyFlags_INDEXED)
+			model.PropertyIndex(prop.IndexId, prop.IndexUid)
+		}
+		if prop.Id > binding.lastId {
+			binding.lastId = prop.Id
+			lastUid = prop.Uid
+		}
+	}
+	model.EntityLastPropertyId(binding.lastId, lastUid)
+
+	if binding.entity != nil && len(binding.entity.properties) > 0 {
+		binding.idProperty = binding.entity.properties[0]
+	}
+}
+
+func (binding *dynamicBinding) GetId(object interface{}) (uint64, error) {
+	var obj, ok = object.(DynamicObject)
+	if !ok {
+		return 0, fmt.Errorf("expected a DynamicObject, got %T", object)
+	}
+
+	var id = obj[binding.definition.IdProperty]
+	if id == nil {
+		return 0, nil
+	} else if bits, isInt := integerBits(reflect.ValueOf(id)); isInt {
+		return bits, nil
+	}
+	return 0, fmt.Errorf("ID property %s must be an integer, got %T", binding.definition.IdProperty, id)
+}
+
+func (binding *dynamicBinding) SetId(object interface{}, id uint64) error {
+	var obj, ok = object.(DynamicObject)
+	if !ok {
+		return fmt.Errorf("expected a DynamicObject, got %T", object)
+	}
+	obj[binding.definition.IdProperty] = id
+	return nil
+}
+
+func (binding *dynamicBinding) PutRelated(ob *ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+func (binding *dynamicBinding) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	var obj, ok = object.(DynamicObject)
+	if !ok {
+		return fmt.Errorf("expected a DynamicObject, got %T", object)
+	}
+
+	var values = make(map[TypeId]interface{}, len(obj))
+	for name, value := range obj {
+		var prop = binding.property(name)
+		if prop == nil {
+			return fmt.Errorf("entity %s has no property %s", binding.entity.name, name)
+		} else if prop == binding.idProperty {
+			continue
+		}
+
+		value, err := prop.patchValue(value)
+		if err != nil {
+			return err
+		} else if value != nil {
+			values[prop.id] = value
+		}
+	}
+	values[binding.idProperty.id] = id
+
+	binding.entity.flatten(fbb, values, binding.lastId)
+	return nil
+}
+
+func (binding *dynamicBinding) Load(ob *ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 {
+		return nil, fmt.Errorf("can't deserialize an object of entity %s - no data received", binding.entity.name)
+	}
+
+	var table = &flatbuffers.Table{Bytes: bytes, Pos: flatbuffers.GetUOffsetT(bytes)}
+	var obj = make(DynamicObject, len(binding.entity.properties))
+	for _, prop := range binding.entity.properties {
+		value, err := prop.read(table)
+		if err != nil {
+			return nil, err
+		} else if value != nil {
+			obj[prop.name] = dynamicValue(prop, value)
+		}
+	}
+	return obj, nil
+}
+
+// dynamicValue converts the value returned by property.read() to the type documented on DynamicObject
+func dynamicValue(prop *property, value interface{}) interface{} {
+	switch prop.propertyType {
+	case C.OBXPropertyType_Byte:
+		return uint8(value.(uint64))
+	case C.OBXPropertyType_Short:
+		return int16(value.(uint64))
+	case C.OBXPropertyType_Int:
+		return int32(value.(uint64))
+	case C.OBXPropertyType_Long:
+		if prop.flags&C.OBXPropertyFlags_ID != 0 {
+			return value.(uint64)
+		}
+		return int64(value.(uint64))
+	case C.OBXPropertyType_Float:
+		return float32(value.(float64))
+	case C.OBXPropertyType_Date:
+		return time.Unix(0, int64(value.(uint64))*int64(time.Millisecond))
+	case C.OBXPropertyType_DateNano:
+		return time.Unix(0, int64(value.(uint64)))
+	case C.OBXPropertyType_ByteVector:
+		// copy, the data is only valid during the transaction
+		return append([]byte{}, value.([]byte)...)
+	}
+	return value
+}
+
+func (binding *dynamicBinding) MakeSlice(capacity int) interface{} {
+	return make([]DynamicObject, 0, capacity)
+}
+
+func (binding *dynamicBinding) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]DynamicObject), nil)
+	}
+	return append(slice.([]DynamicObject), object.(DynamicObject))
+}
+
+func (binding *dynamicBinding) GeneratorVersion() int {
+	return gogen.VersionId
+}
+
+func (binding *dynamicBinding) property(name string) *property {
+	for _, prop := range binding.entity.properties {
+		if prop.name == name {
+			return prop
+		}
+	}
+	return nil
+}
+
+// DynamicBox provides access to objects of an entity registered by Model.RegisterDynamicEntity().
+// The embedded Box works with DynamicObject values, e.g. Box.Query().Find() returns []DynamicObject.
+type DynamicBox struct {
+	*Box
+	binding *dynamicBinding
+}
+
+// DynamicBox returns the box for the dynamic entity with the given name
+func (ob *ObjectBox) DynamicBox(entityName string) (*DynamicBox, error) {
+	var entity = ob.entitiesByName[entityName]
+	if entity == nil {
+		return nil, fmt.Errorf("entity %s is not part of the model", entityName)
+	}
+
+	binding, isDynamic := entity.binding.(*dynamicBinding)
+	if !isDynamic {
+		return nil, fmt.Errorf("entity %s is not a dynamic entity", entityName)
+	}
+
+	box, err := ob.box(entity.id)
+	if err != nil {
+		return nil, err
+	}
+	return &DynamicBox{Box: box, binding: binding}, nil
+}
+
+// Put inserts or updates the object and returns its ID; a new ID is also set on the object
+func (box *DynamicBox) Put(object DynamicObject) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Get reads the object with the given ID; returns nil if it doesn't exist
+func (box *DynamicBox) Get(id uint64) (DynamicObject, error) {
+	object, err := box.Box.Get(id)
+	if object == nil || err != nil {
+		return nil, err
+	}
+	return object.(DynamicObject), nil
+}
+
+// Find returns the objects matching the given conditions, see DynamicBox.Property() for creating the conditions
+func (box *DynamicBox) Find(conditions ...Condition) ([]DynamicObject, error) {
+	query, err := box.Box.QueryOrError(conditions...)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	objects, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]DynamicObject), nil
+}
+
+// Property returns a property of the dynamic entity, used to create query conditions.
+// For an unknown property name, all conditions created from it fail when the query is built.
+func (box *DynamicBox) Property(name string) *DynamicPropertyConditions {
+	return &DynamicPropertyConditions{
+		name:     name,
+		prop:     box.binding.property(name),
+		property: &BaseProperty{Entity: &Entity{Id: box.entity.id}},
+	}
+}
+
+// DynamicPropertyConditions creates query conditions on a property of a dynamic entity, see DynamicBox.Property()
+type DynamicPropertyConditions struct {
+	name     string
+	prop     *property
+	property *BaseProperty
+}
+
+// Equals finds objects with the property value equal to the given one; strings are compared case-sensitive
+func (dp *DynamicPropertyConditions) Equals(value interface{}) Condition {
+	return dp.condition("Equals", value, func(base *BaseProperty, v interface{}) Condition {
+		switch dp.prop.propertyType {
+		case C.OBXPropertyType_Bool:
+			return PropertyBool{base}.Equals(v.(bool))
+		case C.OBXPropertyType_String:
+			return PropertyString{base}.Equals(v.(string), true)
+		case C.OBXPropertyType_ByteVector:
+			return PropertyByteVector{base}.Equals(v.([]byte))
+		case C.OBXPropertyType_Float, C.OBXPropertyType_Double:
+			return PropertyFloat64{base}.Equals(v.(float64), 0)
+		case C.OBXPropertyType_StringVector:
+			return nil
+		}
+		return PropertyInt64{base}.Equals(int64(v.(uint64)))
+	})
+}
+
+// NotEquals finds objects with the property value different from the given one; supports integers and strings
+func (dp *DynamicPropertyConditions) NotEquals(value interface{}) Condition {
+	return dp.condition("NotEquals", value, func(base *BaseProperty, v interface{}) Condition {
+		switch dp.prop.propertyType {
+		case C.OBXPropertyType_String:
+			return PropertyString{base}.NotEquals(v.(string), true)
+		case C.OBXPropertyType_Bool, C.OBXPropertyType_ByteVector, C.OBXPropertyType_Float,
+			C.OBXPropertyType_Double, C.OBXPropertyType_StringVector:
+			return nil
+		}
+		return PropertyInt64{base}.NotEquals(int64(v.(uint64)))
+	})
+}
+
+// GreaterThan finds objects with the property value greater than the given one; supports numbers and strings
+func (dp *DynamicPropertyConditions) GreaterThan(value interface{}) Condition {
+	return dp.condition("GreaterThan", value, func(base *BaseProperty, v interface{}) Condition {
+		switch dp.prop.propertyType {
+		case C.OBXPropertyType_String:
+			return PropertyString{base}.GreaterThan(v.(string), true)
+		case C.OBXPropertyType_Float, C.OBXPropertyType_Double:
+			return PropertyFloat64{base}.GreaterThan(v.(float64))
+		case C.OBXPropertyType_Bool, C.OBXPropertyType_ByteVector, C.OBXPropertyType_StringVector:
+			return nil
+		}
+		return PropertyInt64{base}.GreaterThan(int64(v.(uint64)))
+	})
+}
+
+// LessThan finds objects with the property value less than the given one; supports numbers and strings
+func (dp *DynamicPropertyConditions) LessThan(value interface{}) Condition {
+	return dp.condition("LessThan", value, func(base *BaseProperty, v interface{}) Condition {
+		switch dp.prop.propertyType {
+		case C.OBXPropertyType_String:
+			return PropertyString{base}.LessThan(v.(string), true)
+		case C.OBXPropertyType_Float, C.OBXPropertyType_Double:
+			return PropertyFloat64{base}.LessThan(v.(float64))
+		case C.OBXPropertyType_Bool, C.OBXPropertyType_ByteVector, C.OBXPropertyType_StringVector:
+			return nil
+		}
+		return PropertyInt64{base}.LessThan(int64(v.(uint64)))
+	})
+}
+
+// IsNil finds objects without a stored value of the property
+func (dp *DynamicPropertyConditions) IsNil() Condition {
+	if err := dp.check(); err != nil {
+		return errorCondition(err)
+	}
+	return dp.base().IsNil()
+}
+
+// IsNotNil finds objects with a stored value of the property
+func (dp *DynamicPropertyConditions) IsNotNil() Condition {
+	if err := dp.check(); err != nil {
+		return errorCondition(err)
+	}
+	return dp.base().IsNotNil()
+}
+
+// OrderAsc sets ascending order based on this property
+func (dp *DynamicPropertyConditions) OrderAsc() Condition {
+	if err := dp.check(); err != nil {
+		return errorCondition(err)
+	}
+	return dp.base().orderAsc()
+}
+
+// OrderDesc sets descending order based on this property
+func (dp *DynamicPropertyConditions) OrderDesc() Condition {
+	if err := dp.check(); err != nil {
+		return errorCondition(err)
+	}
+	return dp.base().orderDesc()
+}
+
+func (dp *DynamicPropertyConditions) check() error {
+	if dp.prop == nil {
+		return fmt.Errorf("unknown property %s", dp.name)
+	}
+	return nil
+}
+
+func (dp *DynamicPropertyConditions) base() *BaseProperty {
+	return &BaseProperty{Id: dp.prop.id, Entity: dp.property.Entity}
+}
+
+// condition validates the value (converted to the representation used by property.read()) and creates the condition
+func (dp *DynamicPropertyConditions) condition(name string, value interface{},
+	create func(base *BaseProperty, value interface{}) Condition) Condition {
+	if err := dp.check(); err != nil {
+		return errorCondition(err)
+	}
+
+	converted, err := dp.prop.patchValue(value)
+	if err != nil {
+		return errorCondition(err)
+	} else if converted == nil {
+		return errorCondition(fmt.Errorf("property %s: nil value given to %s, use IsNil() instead", dp.name, name))
+	}
+
+	var condition = create(dp.base(), converted)
+	if condition == nil {
+		return errorCondition(fmt.Errorf("property %s: %s isn't supported for this property type", dp.name, name))
+	}
+	return condition
+}
+
+// errorCondition is a condition failing with the given error when the query is built
+func errorCondition(err error) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return 0, err
+		},
+	}
+}
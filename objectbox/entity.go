@@ -16,6 +16,11 @@
 
 package objectbox
 
+/*
+#include "objectbox.h"
+*/
+import "C"
+
 // Entity is used to specify model in the generated binding code
 type Entity struct {
 	Id TypeId
@@ -31,4 +36,36 @@ type entity struct {
 
 	// whether this entity has any relations (standalone or property-rels) - configured during model creation
 	hasRelations bool
+
+	// properties in the order they were added to the model
+	properties []*property
+
+	// standalone (many-to-many) relations with this entity as the source
+	relations []*RelationToMany
+}
+
+// property holds the model information about a single property, e.g. for reading its value from FlatBuffers data
+type property struct {
+	id           TypeId
+	name         string
+	propertyType int
+	flags        int
+}
+
+func (entity *entity) property(id TypeId) *property {
+	for _, prop := range entity.properties {
+		if prop.id == id {
+			return prop
+		}
+	}
+	return nil
+}
+
+func (entity *entity) idProperty() *property {
+	for _, prop := range entity.properties {
+		if prop.flags&C.OBXPropertyFlags_ID != 0 {
+			return prop
+		}
+	}
+	return nil
 }
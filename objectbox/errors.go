@@ -0,0 +1,106 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import "fmt"
+
+// Error is returned by operations failing in the ObjectBox native library.
+// Compare it to the Err* variables using errors.Is(), e.g. `errors.Is(err, objectbox.ErrUniqueViolation)`,
+// or, before Go 1.13, using a type assertion and its Is() method or Code().
+type Error struct {
+	code          int
+	secondaryCode int
+	message       string
+}
+
+// Error returns the error message
+func (err *Error) Error() string {
+	return err.message
+}
+
+// Code returns the native error code, one of OBX_ERROR_* defined in objectbox.h
+func (err *Error) Code() int {
+	return err.code
+}
+
+// SecondaryCode returns the underlying (possibly platform-specific) error code, e.g. for storage errors; may be 0
+func (err *Error) SecondaryCode() int {
+	return err.secondaryCode
+}
+
+// Is reports whether the target is an *Error with the same code; used by errors.Is()
+func (err *Error) Is(target error) bool {
+	if targetErr, ok := target.(*Error); ok {
+		return err.code == targetErr.code
+	}
+	return false
+}
+
+var (
+	// ErrDbFull - the database reached the maximum size configured by Builder.MaxSizeInKb()
+	ErrDbFull = &Error{code: C.OBX_ERROR_DB_FULL, message: "database is full"}
+
+	// ErrMaxReadersExceeded - too many concurrent read transactions, see Builder.MaxReaders()
+	ErrMaxReadersExceeded = &Error{code: C.OBX_ERROR_MAX_READERS_EXCEEDED, message: "max readers exceeded"}
+
+	// ErrUniqueViolation - a put would store a duplicate value of a property with a unique index
+	ErrUniqueViolation = &Error{code: C.OBX_ERROR_UNIQUE_VIOLATED, message: "unique constraint violated"}
+
+	// ErrNonUniqueResult - more than one object matches a query expected to have a single result
+	ErrNonUniqueResult = &Error{code: C.OBX_ERROR_NON_UNIQUE_RESULT, message: "query result is not unique"}
+
+	// ErrIdAlreadyExists - an insert failed because an object with the same ID is already stored
+	ErrIdAlreadyExists = &Error{code: C.OBX_ERROR_ID_ALREADY_EXISTS, message: "ID already exists"}
+
+	// ErrIdNotFound - an update failed because no object with the given ID is stored
+	ErrIdNotFound = &Error{code: C.OBX_ERROR_ID_NOT_FOUND, message: "ID not found"}
+
+	// ErrFileCorrupt - the database file is corrupt
+	ErrFileCorrupt = &Error{code: C.OBX_ERROR_FILE_CORRUPT, message: "database file is corrupt"}
+
+	// ErrFilePagesCorrupt - corrupt pages were found in the database file, see Builder.ValidateOnOpen()
+	ErrFilePagesCorrupt = &Error{code: C.OBX_ERROR_FILE_PAGES_CORRUPT, message: "database file pages are corrupt"}
+)
+
+// StoreAlreadyOpenError is returned by Builder.BuildOrError() if the database directory is used by another store open
+// in this process. Compare it using errors.Is(err, objectbox.ErrStoreAlreadyOpen) or errors.As() to access the details,
+// or, before Go 1.13, using a type assertion.
+// Note: only stores open in the current process are detected; the native library allows multiple processes to access
+// the same database concurrently, so a store open in another process doesn't cause this error.
+type StoreAlreadyOpenError struct {
+	Directory string
+}
+
+// Error returns the error message
+func (err *StoreAlreadyOpenError) Error() string {
+	return fmt.Sprintf("store in directory %q is already open in this process; close it first or use Builder.WaitForLock()",
+		err.Directory)
+}
+
+// Is reports whether the target is a StoreAlreadyOpenError; used by errors.Is()
+func (err *StoreAlreadyOpenError) Is(target error) bool {
+	_, ok := target.(*StoreAlreadyOpenError)
+	return ok
+}
+
+// ErrStoreAlreadyOpen - the database directory is used by another store open in this process, see StoreAlreadyOpenError
+var ErrStoreAlreadyOpen error = &StoreAlreadyOpenError{}
@@ -0,0 +1,175 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultExpirationBatchSize is used by ExpirationRule if BatchSize is not set
+const defaultExpirationBatchSize = 1000
+
+// ExpirationRule configures removal of objects once the time stored in the given date property has passed.
+// The property holds the expiration time in milliseconds since the Unix epoch, the default representation of dates
+// (e.g. time.Time with the `objectbox:"date"` tag); objects with a zero value never expire.
+type ExpirationRule struct {
+	Property *PropertyInt64
+
+	// BatchSize limits the number of objects removed in a single transaction (default: 1000)
+	BatchSize uint64
+}
+
+// ExpirationWorker periodically removes expired objects; see ObjectBox.StartExpirationWorker()
+type ExpirationWorker struct {
+	ob    *ObjectBox
+	rules []ExpirationRule
+
+	mutex   sync.Mutex
+	purged  map[string]uint64
+	lastErr error
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+// StartExpirationWorker starts a goroutine removing expired objects according to the given rules every interval.
+// Call Stop() on the returned worker before closing the store.
+func (ob *ObjectBox) StartExpirationWorker(interval time.Duration, rules ...ExpirationRule) (*ExpirationWorker, error) {
+	if interval <= 0 {
+		return nil, errors.New("expiration worker interval must be positive")
+	} else if len(rules) == 0 {
+		return nil, errors.New("no expiration rules given")
+	}
+
+	for _, rule := range rules {
+		if rule.Property == nil || rule.Property.BaseProperty == nil || rule.Property.Entity == nil {
+			return nil, errors.New("expiration rule property is not defined")
+		}
+		if _, err := ob.box(rule.Property.Entity.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	var worker = &ExpirationWorker{
+		ob:      ob,
+		rules:   rules,
+		purged:  make(map[string]uint64),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(worker.stopped)
+		var ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-worker.stop:
+				return
+			case <-ticker.C:
+				_ = worker.RunOnce()
+			}
+		}
+	}()
+
+	return worker, nil
+}
+
+// RunOnce immediately removes all objects that have expired by now, in batches configured by the rules.
+// The error is also available by calling LastError().
+func (worker *ExpirationWorker) RunOnce() error {
+	var now = time.Now().UnixNano() / int64(time.Millisecond)
+	var err error
+	for _, rule := range worker.rules {
+		if err = worker.purge(rule, now); err != nil {
+			break
+		}
+	}
+
+	worker.mutex.Lock()
+	worker.lastErr = err
+	worker.mutex.Unlock()
+	return err
+}
+
+func (worker *ExpirationWorker) purge(rule ExpirationRule, now int64) error {
+	var batchSize = rule.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultExpirationBatchSize
+	}
+
+	box, err := worker.ob.box(rule.Property.Entity.Id)
+	if err != nil {
+		return err
+	}
+
+	// expired objects are removed from the database even if they're marked as deleted or soft delete is enabled
+	query, err := box.QueryIncludingDeleted(rule.Property.Between(1, now))
+	if err != nil {
+		return err
+	}
+	defer query.Close()
+	query.Limit(batchSize)
+
+	for {
+		var removed uint64
+		err := worker.ob.RunInWriteTx(func() error {
+			ids, err := query.findIds()
+			if err == nil && len(ids) > 0 {
+				removed, err = box.RemoveHard(ids...)
+			}
+			return err
+		})
+		if removed > 0 {
+			worker.mutex.Lock()
+			worker.purged[box.entity.name] += removed
+			worker.mutex.Unlock()
+		}
+
+		if err != nil || removed < batchSize {
+			return err
+		}
+	}
+}
+
+// Purged returns the total number of removed objects per entity name since the worker was started
+func (worker *ExpirationWorker) Purged() map[string]uint64 {
+	worker.mutex.Lock()
+	defer worker.mutex.Unlock()
+
+	var result = make(map[string]uint64, len(worker.purged))
+	for name, count := range worker.purged {
+		result[name] = count
+	}
+	return result
+}
+
+// LastError returns the error of the last run, or nil if it succeeded
+func (worker *ExpirationWorker) LastError() error {
+	worker.mutex.Lock()
+	defer worker.mutex.Unlock()
+	return worker.lastErr
+}
+
+// Stop stops the worker and waits until a run in progress, if any, finishes; it's safe to call it concurrently
+func (worker *ExpirationWorker) Stop() {
+	worker.stopOnce.Do(func() { close(worker.stop) })
+	<-worker.stopped
+}
@@ -90,6 +90,94 @@ func GetStringVectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT)
 	return nil
 }
 
+// GetInt64VectorSlot provides access to the FlatBuffers table
+func GetInt64VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []int64 {
+	if vector := GetInt64VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetInt64VectorPtrSlot provides access to the FlatBuffers table
+func GetInt64VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]int64 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]int64, ln)
+		for i := range values {
+			values[i] = table.GetInt64(start + flatbuffers.UOffsetT(i*flatbuffers.SizeInt64))
+		}
+		return &values
+	}
+	return nil
+}
+
+// GetInt32VectorSlot provides access to the FlatBuffers table
+func GetInt32VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []int32 {
+	if vector := GetInt32VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetInt32VectorPtrSlot provides access to the FlatBuffers table
+func GetInt32VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]int32 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]int32, ln)
+		for i := range values {
+			values[i] = table.GetInt32(start + flatbuffers.UOffsetT(i*flatbuffers.SizeInt32))
+		}
+		return &values
+	}
+	return nil
+}
+
+// GetFloat64VectorSlot provides access to the FlatBuffers table
+func GetFloat64VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []float64 {
+	if vector := GetFloat64VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetFloat64VectorPtrSlot provides access to the FlatBuffers table
+func GetFloat64VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]float64 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]float64, ln)
+		for i := range values {
+			values[i] = table.GetFloat64(start + flatbuffers.UOffsetT(i*flatbuffers.SizeFloat64))
+		}
+		return &values
+	}
+	return nil
+}
+
+// GetFloat32VectorSlot provides access to the FlatBuffers table
+func GetFloat32VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []float32 {
+	if vector := GetFloat32VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetFloat32VectorPtrSlot provides access to the FlatBuffers table
+func GetFloat32VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]float32 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]float32, ln)
+		for i := range values {
+			values[i] = table.GetFloat32(start + flatbuffers.UOffsetT(i*flatbuffers.SizeFloat32))
+		}
+		return &values
+	}
+	return nil
+}
+
 // GetBoolSlot provides access to the FlatBuffers table
 func GetBoolSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) bool {
 	return table.GetBoolSlot(slot, false)
@@ -47,6 +47,58 @@ func CreateStringVectorOffset(fbb *flatbuffers.Builder, values []string) flatbuf
 	return createOffsetVector(fbb, offsets)
 }
 
+// CreateInt64VectorOffset creates an offset in the FlatBuffers table
+func CreateInt64VectorOffset(fbb *flatbuffers.Builder, values []int64) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(flatbuffers.SizeInt64, len(values), flatbuffers.SizeInt64)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependInt64(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// CreateInt32VectorOffset creates an offset in the FlatBuffers table
+func CreateInt32VectorOffset(fbb *flatbuffers.Builder, values []int32) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(flatbuffers.SizeInt32, len(values), flatbuffers.SizeInt32)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependInt32(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// CreateFloat64VectorOffset creates an offset in the FlatBuffers table
+func CreateFloat64VectorOffset(fbb *flatbuffers.Builder, values []float64) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(flatbuffers.SizeFloat64, len(values), flatbuffers.SizeFloat64)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependFloat64(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// CreateFloat32VectorOffset creates an offset in the FlatBuffers table
+func CreateFloat32VectorOffset(fbb *flatbuffers.Builder, values []float32) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(flatbuffers.SizeFloat32, len(values), flatbuffers.SizeFloat32)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependFloat32(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
 func createOffsetVector(fbb *flatbuffers.Builder, offsets []flatbuffers.UOffsetT) flatbuffers.UOffsetT {
 	fbb.StartVector(int(flatbuffers.SizeUOffsetT), len(offsets), int(flatbuffers.SizeUOffsetT))
 	for i := len(offsets) - 1; i >= 0; i-- {
@@ -191,3 +191,52 @@ func readObject(data []byte) entity {
 		Float64:      table.GetFloat64Slot(38, 0),
 	}
 }
+
+func TestScalarVectors(t *testing.T) {
+	var int64s = []int64{-1, 0, 1 << 40}
+	var int32s = []int32{-2, 0, 1 << 20}
+	var float64s = []float64{-3.5, 0, 1.25}
+	var float32s = []float32{-4.5, 0, 2.25}
+
+	var fbb = flatbuffers.NewBuilder(512)
+	var offsetInt64s = CreateInt64VectorOffset(fbb, int64s)
+	var offsetInt32s = CreateInt32VectorOffset(fbb, int32s)
+	var offsetFloat64s = CreateFloat64VectorOffset(fbb, float64s)
+	var offsetFloat32s = CreateFloat32VectorOffset(fbb, float32s)
+	var offsetEmpty = CreateInt64VectorOffset(fbb, []int64{})
+	var offsetNil = CreateFloat32VectorOffset(fbb, nil)
+
+	fbb.StartObject(6)
+	SetUOffsetTSlot(fbb, 0, offsetInt64s)
+	SetUOffsetTSlot(fbb, 1, offsetInt32s)
+	SetUOffsetTSlot(fbb, 2, offsetFloat64s)
+	SetUOffsetTSlot(fbb, 3, offsetFloat32s)
+	SetUOffsetTSlot(fbb, 4, offsetEmpty)
+	SetUOffsetTSlot(fbb, 5, offsetNil)
+	fbb.Finish(fbb.EndObject())
+
+	// read from an "unsafe" copy and clear the source to make sure the results don't point to the original memory
+	var objBytesManaged = fbb.FinishedBytes()
+	var unsafeBytes = getUnsafeBytes(objBytesManaged)
+	var table = &flatbuffers.Table{
+		Bytes: unsafeBytes,
+		Pos:   flatbuffers.GetUOffsetT(unsafeBytes),
+	}
+
+	var readInt64s = GetInt64VectorSlot(table, 4)
+	var readInt32s = GetInt32VectorSlot(table, 6)
+	var readFloat64s = GetFloat64VectorSlot(table, 8)
+	var readFloat32s = GetFloat32VectorSlot(table, 10)
+	var readEmpty = GetInt64VectorPtrSlot(table, 12)
+	var readNil = GetFloat32VectorPtrSlot(table, 14)
+
+	clearBytes(&objBytesManaged)
+
+	assert.Eq(t, int64s, readInt64s)
+	assert.Eq(t, int32s, readInt32s)
+	assert.Eq(t, float64s, readFloat64s)
+	assert.Eq(t, float32s, readFloat32s)
+	assert.True(t, readEmpty != nil)
+	assert.Eq(t, 0, len(*readEmpty))
+	assert.True(t, readNil == nil)
+}
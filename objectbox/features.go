@@ -0,0 +1,76 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+// Features describes the capabilities of the loaded ObjectBox native library, see LibFeatures()
+type Features struct {
+	// Version of the loaded native library, see VersionLib()
+	Version Version
+
+	// Sync client support, see SyncIsAvailable()
+	Sync bool
+
+	// Sync server support
+	SyncServer bool
+
+	// Admin web UI, see AdminIsAvailable()
+	Admin bool
+
+	// Tree API, i.e. hierarchical data
+	Tree bool
+
+	// TimeSeries support
+	TimeSeries bool
+
+	// DebugLog can be enabled for additional diagnostics
+	DebugLog bool
+
+	// InMemory databases using the "memory:" directory prefix, see Builder.InMemory()
+	InMemory bool
+
+	// Encryption of the database, see Builder.EncryptionKey()
+	Encryption bool
+
+	// VectorSearch, i.e. nearest neighbour queries on vector properties
+	VectorSearch bool
+}
+
+// LibFeatures returns the capabilities of the loaded ObjectBox native library, e.g. to check whether it supports Sync
+// before configuring it, instead of failing later. Encryption and VectorSearch are reported as not available, as no
+// library version supported by this binding provides them.
+func LibFeatures() Features {
+	return Features{
+		Version:    VersionLib(),
+		Sync:       bool(C.obx_has_feature(C.OBXFeature_Sync)),
+		SyncServer: bool(C.obx_has_feature(C.OBXFeature_SyncServer)),
+		Admin:      bool(C.obx_has_feature(C.OBXFeature_Admin)),
+		Tree:       bool(C.obx_has_feature(C.OBXFeature_Tree)),
+		TimeSeries: bool(C.obx_has_feature(C.OBXFeature_TimeSeries)),
+		DebugLog:   bool(C.obx_has_feature(C.OBXFeature_DebugLog)),
+		InMemory:   InMemoryIsAvailable(),
+	}
+}
+
+// Features returns the capabilities of the native library this store runs on, see LibFeatures()
+func (ob *ObjectBox) Features() Features {
+	return LibFeatures()
+}
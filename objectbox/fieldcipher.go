@@ -0,0 +1,156 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// Cipher encrypts and decrypts values of individual properties, see SetFieldCipher()
+type Cipher interface {
+	Encrypt(plaintext []byte) (ciphertext []byte, err error)
+	Decrypt(ciphertext []byte) (plaintext []byte, err error)
+}
+
+// ErrNoFieldCipher is returned by the field encryption helpers if no cipher was installed, see SetFieldCipher()
+var ErrNoFieldCipher = errors.New("no field cipher installed")
+
+var fieldCipher struct {
+	sync.RWMutex
+	cipher Cipher
+}
+
+// SetFieldCipher installs the cipher used to encrypt selected properties of your entities (e.g. tokens or personal
+// data). Other properties stay unencrypted, so their indexes and queries keep working; the encrypted ones can't be
+// queried.
+//
+// The encryption happens in property converters using EncryptFieldString/DecryptFieldString (or the Bytes variants),
+// e.g. for a string field stored as an encrypted byte vector:
+//
+//	type User struct {
+//		Id    uint64
+//		Token string `objectbox:"type:[]byte converter:encrypted"`
+//	}
+//
+//	func encryptedToDatabaseValue(goValue string) ([]byte, error) { return objectbox.EncryptFieldString(goValue) }
+//	func encryptedToEntityProperty(dbValue []byte) (string, error) { return objectbox.DecryptFieldString(dbValue) }
+//
+// As converters don't know the store they're used with, the cipher applies to the whole process, i.e. to all stores.
+// Install it once, before the first encrypted property is read or written. Installing a different cipher while one
+// is installed fails, because data encrypted by the previous cipher couldn't be decrypted anymore; pass nil to remove
+// the installed cipher first. See NewAESCipher() for a default cipher.
+func SetFieldCipher(c Cipher) error {
+	fieldCipher.Lock()
+	defer fieldCipher.Unlock()
+
+	if c != nil && fieldCipher.cipher != nil && !sameCipher(c, fieldCipher.cipher) {
+		return errors.New("a different field cipher is already installed")
+	}
+	fieldCipher.cipher = c
+	return nil
+}
+
+// sameCipher compares ciphers without panicking on types that aren't comparable
+func sameCipher(a, b Cipher) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
+func getFieldCipher() (Cipher, error) {
+	fieldCipher.RLock()
+	defer fieldCipher.RUnlock()
+	if fieldCipher.cipher == nil {
+		return nil, ErrNoFieldCipher
+	}
+	return fieldCipher.cipher, nil
+}
+
+// EncryptFieldBytes encrypts a property value using the cipher installed by SetFieldCipher().
+// A nil or empty value stays nil, i.e. isn't stored.
+func EncryptFieldBytes(goValue []byte) ([]byte, error) {
+	if len(goValue) == 0 {
+		return nil, nil
+	}
+	c, err := getFieldCipher()
+	if err != nil {
+		return nil, err
+	}
+	return c.Encrypt(goValue)
+}
+
+// DecryptFieldBytes decrypts a property value encrypted by EncryptFieldBytes()
+func DecryptFieldBytes(dbValue []byte) ([]byte, error) {
+	if len(dbValue) == 0 {
+		return nil, nil
+	}
+	c, err := getFieldCipher()
+	if err != nil {
+		return nil, err
+	}
+	return c.Decrypt(dbValue)
+}
+
+// EncryptFieldString encrypts a string property value, see EncryptFieldBytes()
+func EncryptFieldString(goValue string) ([]byte, error) {
+	return EncryptFieldBytes([]byte(goValue))
+}
+
+// DecryptFieldString decrypts a string property value encrypted by EncryptFieldString()
+func DecryptFieldString(dbValue []byte) (string, error) {
+	plaintext, err := DecryptFieldBytes(dbValue)
+	return string(plaintext), err
+}
+
+// aesCipher implements Cipher using AES-GCM; the random nonce is stored in front of the ciphertext
+type aesCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESCipher creates a Cipher using AES-GCM with the given 16, 24 or 32 bytes long key (AES-128, -192 or -256).
+// Each value is encrypted with a random nonce, i.e. equal values result in different ciphertexts.
+func NewAESCipher(key []byte) (Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesCipher{aead: aead}, nil
+}
+
+func (c *aesCipher) Encrypt(plaintext []byte) ([]byte, error) {
+	var nonce = make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(plaintext)+c.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (c *aesCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	var nonceSize = c.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("encrypted value is too short")
+	}
+	return c.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+}
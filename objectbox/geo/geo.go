@@ -0,0 +1,163 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package geo provides geospatial query helpers for entities storing a location as a pair of latitude and longitude
+// float64 properties (in degrees).
+//
+// Radius queries are executed in two steps: WithinRadius() creates a bounding box condition as a pre-filter which
+// the database can evaluate, and FilterWithinRadius() drops the objects outside of the actual circle from the result.
+//
+//	var center = geo.Point{Lat: 48.137, Lng: 11.575}
+//	objects, err := box.Query(geo.WithinRadius(Place_.Lat, Place_.Lng, center, 5000)).Find()
+//	if err == nil {
+//		objects = geo.FilterWithinRadius(objects, center, 5000, func(object interface{}) geo.Point {
+//			return geo.Point{Lat: object.(*Place).Lat, Lng: object.(*Place).Lng}
+//		}).([]*Place)
+//	}
+package geo
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// EarthRadius is the mean radius of the Earth in meters, as used for distance calculations
+const EarthRadius = 6371008.8
+
+// Point is a location given by its latitude and longitude in degrees
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// String returns the point formatted as "lat,lng"
+func (p Point) String() string {
+	return fmt.Sprintf("%f,%f", p.Lat, p.Lng)
+}
+
+// Distance returns the great-circle distance between the given points in meters, using the haversine formula
+func Distance(a, b Point) float64 {
+	var lat1 = radians(a.Lat)
+	var lat2 = radians(b.Lat)
+	var sinLat = math.Sin(radians(b.Lat-a.Lat) / 2)
+	var sinLng = math.Sin(radians(b.Lng-a.Lng) / 2)
+	var h = sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLng*sinLng
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox is an area between two latitudes and two longitudes.
+// If Min.Lng is greater than Max.Lng, the box crosses the antimeridian (180th meridian).
+type BoundingBox struct {
+	Min Point // south-west corner
+	Max Point // north-east corner
+}
+
+// Contains returns true if the given point lies within the bounding box (including its border)
+func (bb BoundingBox) Contains(p Point) bool {
+	if p.Lat < bb.Min.Lat || p.Lat > bb.Max.Lat {
+		return false
+	}
+	if bb.crossesAntimeridian() {
+		return p.Lng >= bb.Min.Lng || p.Lng <= bb.Max.Lng
+	}
+	return p.Lng >= bb.Min.Lng && p.Lng <= bb.Max.Lng
+}
+
+func (bb BoundingBox) crossesAntimeridian() bool {
+	return bb.Min.Lng > bb.Max.Lng
+}
+
+// RadiusBoundingBox returns the smallest bounding box containing the circle with the given center and radius (meters).
+// Close to the poles, the box covers all longitudes.
+func RadiusBoundingBox(center Point, meters float64) BoundingBox {
+	var dLat = degrees(meters / EarthRadius)
+	var bb = BoundingBox{
+		Min: Point{Lat: center.Lat - dLat, Lng: -180},
+		Max: Point{Lat: center.Lat + dLat, Lng: 180},
+	}
+
+	// a circle reaching over a pole includes all longitudes
+	if bb.Min.Lat <= -90 || bb.Max.Lat >= 90 {
+		bb.Min.Lat = math.Max(bb.Min.Lat, -90)
+		bb.Max.Lat = math.Min(bb.Max.Lat, 90)
+		return bb
+	}
+
+	var dLng = degrees(math.Asin(math.Min(1, math.Sin(meters/EarthRadius)/math.Cos(radians(center.Lat)))))
+	if dLng < 180 {
+		bb.Min.Lng = normalizeLng(center.Lng - dLng)
+		bb.Max.Lng = normalizeLng(center.Lng + dLng)
+	}
+	return bb
+}
+
+// WithinBoundingBox creates a condition matching objects located within the given bounding box (including its border)
+func WithinBoundingBox(lat, lng *objectbox.PropertyFloat64, bb BoundingBox) objectbox.Condition {
+	var lngCondition objectbox.Condition
+	if bb.crossesAntimeridian() {
+		lngCondition = objectbox.Any(lng.GreaterOrEqual(bb.Min.Lng), lng.LessOrEqual(bb.Max.Lng))
+	} else {
+		lngCondition = lng.Between(bb.Min.Lng, bb.Max.Lng)
+	}
+	return objectbox.All(lat.Between(bb.Min.Lat, bb.Max.Lat), lngCondition)
+}
+
+// WithinRadius creates a condition matching objects located within the bounding box of the given circle.
+// It's a pre-filter only - the corners of the box lie outside of the circle - use FilterWithinRadius() on the results.
+func WithinRadius(lat, lng *objectbox.PropertyFloat64, center Point, meters float64) objectbox.Condition {
+	return WithinBoundingBox(lat, lng, RadiusBoundingBox(center, meters))
+}
+
+// FilterWithinRadius returns a new slice with only those objects of the given slice (e.g. []*Place as returned by
+// a query) which are located within the given radius (meters) from the center. The location of each object is
+// provided by the position callback. The result has the same type as the given slice.
+func FilterWithinRadius(objects interface{}, center Point, meters float64,
+	position func(object interface{}) Point) interface{} {
+	var slice = reflect.ValueOf(objects)
+	if slice.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("objects must be a slice, %s given", slice.Kind()))
+	}
+
+	var result = reflect.MakeSlice(slice.Type(), 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		var object = slice.Index(i)
+		if Distance(center, position(object.Interface())) <= meters {
+			result = reflect.Append(result, object)
+		}
+	}
+	return result.Interface()
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// normalizeLng wraps the given longitude to the range [-180, 180]
+func normalizeLng(lng float64) float64 {
+	if lng < -180 {
+		return lng + 360
+	} else if lng > 180 {
+		return lng - 360
+	}
+	return lng
+}
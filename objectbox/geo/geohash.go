@@ -0,0 +1,153 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// geohashAlphabet is the base32 alphabet used by geohashes
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// GeohashMaxPrecision is the maximum supported geohash length; 12 characters describe an area smaller than 4cm
+const GeohashMaxPrecision = 12
+
+// Geohash encodes the given point as a geohash of the given length (precision, 1 to GeohashMaxPrecision).
+// Store it in an indexed string property to find nearby objects using WithinGeohashes().
+func Geohash(p Point, precision int) string {
+	if precision < 1 {
+		precision = 1
+	} else if precision > GeohashMaxPrecision {
+		precision = GeohashMaxPrecision
+	}
+
+	var latRange = [2]float64{-90, 90}
+	var lngRange = [2]float64{-180, 180}
+	var hash = make([]byte, precision)
+	var even = true // even bits encode the longitude, odd bits the latitude
+	for i := range hash {
+		var char byte
+		for bit := 0; bit < 5; bit++ {
+			var value, valueRange = p.Lat, &latRange
+			if even {
+				value, valueRange = p.Lng, &lngRange
+			}
+
+			var mid = (valueRange[0] + valueRange[1]) / 2
+			char <<= 1
+			if value >= mid {
+				char |= 1
+				valueRange[0] = mid
+			} else {
+				valueRange[1] = mid
+			}
+			even = !even
+		}
+		hash[i] = geohashAlphabet[char]
+	}
+	return string(hash)
+}
+
+// GeohashBoundingBox returns the area described by the given geohash
+func GeohashBoundingBox(hash string) (BoundingBox, error) {
+	if len(hash) == 0 || len(hash) > GeohashMaxPrecision {
+		return BoundingBox{}, fmt.Errorf("invalid geohash length %d", len(hash))
+	}
+
+	var latRange = [2]float64{-90, 90}
+	var lngRange = [2]float64{-180, 180}
+	var even = true
+	for i := 0; i < len(hash); i++ {
+		var char = strings.IndexByte(geohashAlphabet, hash[i])
+		if char < 0 {
+			return BoundingBox{}, fmt.Errorf("invalid geohash character %q", hash[i])
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			var valueRange = &latRange
+			if even {
+				valueRange = &lngRange
+			}
+
+			var mid = (valueRange[0] + valueRange[1]) / 2
+			if char&(1<<uint(bit)) != 0 {
+				valueRange[0] = mid
+			} else {
+				valueRange[1] = mid
+			}
+			even = !even
+		}
+	}
+
+	return BoundingBox{
+		Min: Point{Lat: latRange[0], Lng: lngRange[0]},
+		Max: Point{Lat: latRange[1], Lng: lngRange[1]},
+	}, nil
+}
+
+// GeohashCoverage returns geohashes of the given precision which together cover the given bounding box.
+// The number of geohashes grows quickly with the precision; choose a precision whose cells are about as large as the
+// box, e.g. using a radius bounding box and precision 5 (~5km cells) for a radius of a few kilometers.
+func GeohashCoverage(bb BoundingBox, precision int) []string {
+	if bb.crossesAntimeridian() {
+		var west = BoundingBox{Min: bb.Min, Max: Point{Lat: bb.Max.Lat, Lng: 180}}
+		var east = BoundingBox{Min: Point{Lat: bb.Min.Lat, Lng: -180}, Max: bb.Max}
+		return append(GeohashCoverage(west, precision), GeohashCoverage(east, precision)...)
+	}
+
+	var cellHeight, cellWidth = geohashCellSize(Geohash(bb.Min, precision))
+
+	var hashes []string
+	var seen = make(map[string]bool)
+	for lat := bb.Min.Lat; ; lat = math.Min(lat+cellHeight, bb.Max.Lat) {
+		for lng := bb.Min.Lng; ; lng = math.Min(lng+cellWidth, bb.Max.Lng) {
+			var hash = Geohash(Point{Lat: lat, Lng: lng}, precision)
+			if !seen[hash] {
+				seen[hash] = true
+				hashes = append(hashes, hash)
+			}
+			if lng >= bb.Max.Lng {
+				break
+			}
+		}
+		if lat >= bb.Max.Lat {
+			break
+		}
+	}
+	return hashes
+}
+
+// geohashCellSize returns the height (latitude) and width (longitude) of the cells of the given geohash's precision
+func geohashCellSize(hash string) (height, width float64) {
+	var bb, _ = GeohashBoundingBox(hash)
+	return bb.Max.Lat - bb.Min.Lat, bb.Max.Lng - bb.Min.Lng
+}
+
+// WithinGeohashes creates a condition matching objects whose geohash property starts with any of the given geohashes,
+// e.g. as returned by GeohashCoverage(). Using an index on the property makes this a fast pre-filter; combine it with
+// FilterWithinRadius() or BoundingBox.Contains() to get precise results.
+func WithinGeohashes(geohash *objectbox.PropertyString, hashes ...string) objectbox.Condition {
+	var conditions = make([]objectbox.Condition, len(hashes))
+	for i, hash := range hashes {
+		conditions[i] = geohash.HasPrefix(hash, true)
+	}
+	return objectbox.Any(conditions...)
+}
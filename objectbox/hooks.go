@@ -0,0 +1,45 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+// BeforePutHook can be implemented by an entity (pointer receiver) to be notified before the object is stored,
+// e.g. to stamp an UpdatedAt field or to update computed fields. Changes made to the object are persisted.
+// Returning an error aborts the put; the error is returned by the Put call.
+type BeforePutHook interface {
+	BeforePut() error
+}
+
+// AfterGetHook can be implemented by an entity (pointer receiver) to be notified after the object was read,
+// e.g. to populate fields that aren't stored. Returning an error fails the read operation.
+type AfterGetHook interface {
+	AfterGet() error
+}
+
+// load constructs the object using the binding and calls its AfterGet hook, if implemented
+func (entity *entity) load(ob *ObjectBox, bytes []byte) (interface{}, error) {
+	object, err := entity.binding.Load(ob, bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if hook, ok := object.(AfterGetHook); ok {
+		if err = hook.AfterGet(); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
@@ -18,3 +18,24 @@ func TestLargeArraySupport(t *testing.T) {
 			"in the ObjectBox core library", runtime.GOARCH)
 	}
 }
+
+func TestCheckLibVersion(t *testing.T) {
+	var min = VersionLibMin()
+	for _, found := range []Version{
+		{min.Major, min.Minor - 1, 0, ""},
+		{VersionLibStatic().Major + 1, 0, 0, ""},
+	} {
+		err := checkLibVersion(found)
+		if versionErr, ok := err.(*IncompatibleCoreVersionError); !ok {
+			t.Errorf("Expected an IncompatibleCoreVersionError for version %v but got %v", found, err)
+		} else if versionErr.Found != found || versionErr.Min != min {
+			t.Errorf("Unexpected versions in the error: %v", versionErr)
+		}
+	}
+
+	for _, found := range []Version{min, VersionLibStatic(), {min.Major, min.Minor + 10, 0, ""}} {
+		if err := checkLibVersion(found); err != nil {
+			t.Errorf("Unexpected error for version %v: %v", found, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"unsafe"
+)
+
+// JSONImportMode defines how Box.ImportJSON treats objects with IDs
+type JSONImportMode int
+
+const (
+	// JSONImportInsert inserts the objects with their IDs; fails if an object with the same ID already exists.
+	// Note: unless the ID is self-assignable (`objectbox:"id(assignable)"`), the ID must be known to the store.
+	JSONImportInsert JSONImportMode = iota
+
+	// JSONImportUpsert inserts new objects and overwrites the existing ones with the same ID (like Box.Put)
+	JSONImportUpsert
+
+	// JSONImportNew ignores the IDs in the input and inserts all objects with newly assigned IDs
+	JSONImportNew
+)
+
+// ExportJSON writes all objects in the box as JSON lines, i.e. one JSON object per line.
+// Objects are encoded using the standard "encoding/json" package so `json` struct tags are respected.
+func (box *Box) ExportJSON(w io.Writer) error {
+	var encoder = json.NewEncoder(w)
+
+	var err error
+	visitor, err2 := dataVisitorRegister(func(bytes []byte) bool {
+		object, err2 := box.entity.load(box.ObjectBox, bytes)
+		if err2 == nil {
+			err2 = encoder.Encode(object)
+		}
+		if err2 != nil {
+			err = err2
+			return false
+		}
+		return true
+	})
+	if err2 != nil {
+		return err2
+	}
+	defer dataVisitorUnregister(visitor)
+
+	// use another `error` variable as `err` may be set by the visitor callback above
+	err2 = box.ObjectBox.RunInReadTx(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_box_visit_all(box.cBox, dataVisitor, unsafe.Pointer(&visitor))
+		})
+	})
+
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
+
+// ImportJSON reads JSON objects (e.g. as written by ExportJSON) and puts them into the box in a single transaction.
+// Returns the number of imported objects; in case of an error, the transaction is rolled back and nothing is imported.
+// Only boxes of generated (struct) entities are supported, not dynamic entities.
+func (box *Box) ImportJSON(r io.Reader, mode JSONImportMode) (count uint64, err error) {
+	var putMode C.OBXPutMode
+	switch mode {
+	case JSONImportInsert, JSONImportNew:
+		putMode = cPutModeInsert
+	case JSONImportUpsert:
+		putMode = cPutModePut
+	default:
+		return 0, fmt.Errorf("unknown JSON import mode %d", mode)
+	}
+
+	// the binding slice element is either a pointer to the entity struct, e.g. []*Task, or the struct itself when
+	// the entity is read by value, e.g. []Task; other kinds (such as dynamic entities' maps) can't be decoded here
+	var elemType = reflect.TypeOf(box.entity.binding.MakeSlice(0)).Elem()
+	var byValue bool
+	var structType reflect.Type
+	switch {
+	case elemType.Kind() == reflect.Ptr && elemType.Elem().Kind() == reflect.Struct:
+		structType = elemType.Elem()
+	case elemType.Kind() == reflect.Struct:
+		structType = elemType
+		byValue = true
+	default:
+		return 0, fmt.Errorf("JSON import is not supported for objects of type %s", elemType)
+	}
+
+	var decoder = json.NewDecoder(r)
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		for {
+			var ptr = reflect.New(structType)
+			if err := decoder.Decode(ptr.Interface()); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("can't decode object %d: %s", count+1, err)
+			}
+
+			// generated bindings accept a pointer in SetId() even for by-value entities, so reset the ID before
+			// possibly dereferencing the object
+			if mode == JSONImportNew {
+				if err := box.entity.binding.SetId(ptr.Interface(), 0); err != nil {
+					return err
+				}
+			}
+
+			var object = ptr.Interface()
+			if byValue {
+				object = ptr.Elem().Interface()
+			}
+
+			if _, err := box.put(object, true, putMode); err != nil {
+				return err
+			}
+			count++
+		}
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,28 @@
+//go:build !objectbox_static
+// +build !objectbox_static
+
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+// By default, the ObjectBox native library is linked dynamically, i.e. it must be installed on the target machine.
+// Build with the "objectbox_static" tag to link a static library instead, see link_static.go.
+
+/*
+#cgo LDFLAGS: -lobjectbox
+*/
+import "C"
@@ -0,0 +1,32 @@
+//go:build objectbox_static
+// +build objectbox_static
+
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+// Building with the "objectbox_static" tag (e.g. `go build -tags objectbox_static`) links the ObjectBox native library
+// statically so that the resulting binary doesn't depend on libobjectbox being installed on the target machine.
+// The static library (libobjectbox.a) must be available to the linker, e.g. using CGO_LDFLAGS="-L/path/to/lib".
+// The native library is written in C++, thus its runtime needs to be linked as well.
+
+/*
+#cgo linux LDFLAGS: -Wl,-Bstatic -lobjectbox -Wl,-Bdynamic -lstdc++ -lm -lpthread -ldl
+#cgo darwin LDFLAGS: -lobjectbox -lc++
+#cgo windows LDFLAGS: -lobjectbox -lstdc++
+*/
+import "C"
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// LinkBox manages objects of a "link entity" connecting two entities (many-to-many) while carrying attributes of the
+// link, e.g. a Membership with a Role and a Since date, linking users to groups:
+//
+//	type Membership struct {
+//		Id    uint64
+//		User  *User  `objectbox:"link"`
+//		Group *Group `objectbox:"link"`
+//		Role  string
+//		Since time.Time `objectbox:"date"`
+//	}
+//
+//	memberships, err := ob.LinkBox(Membership_.User, Membership_.Group)
+//
+// As opposed to a standalone relation (RelationToMany), the links are regular objects, so they can hold any data and
+// be queried by it. LinkBox keeps at most one link for each pair of source and target objects.
+type LinkBox struct {
+	*Box
+	source     *RelationToOne
+	target     *RelationToOne
+	sourceProp *property
+	targetProp *property
+}
+
+// LinkBox returns a LinkBox for the link entity having the given to-one relations to the source and target entities
+func (ob *ObjectBox) LinkBox(source, target *RelationToOne) (*LinkBox, error) {
+	if source == nil || source.Property == nil || source.Property.Entity == nil ||
+		target == nil || target.Property == nil || target.Property.Entity == nil {
+		return nil, errors.New("relation is not defined")
+	} else if source.Property.Entity.Id != target.Property.Entity.Id {
+		return nil, errors.New("source and target relations must belong to the same link entity")
+	} else if source.Property.Id == target.Property.Id {
+		return nil, errors.New("source and target relations must be different")
+	}
+
+	box, err := ob.box(source.Property.Entity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var linkBox = &LinkBox{
+		Box:        box,
+		source:     source,
+		target:     target,
+		sourceProp: box.entity.property(source.Property.Id),
+		targetProp: box.entity.property(target.Property.Id),
+	}
+	if linkBox.sourceProp == nil || linkBox.targetProp == nil {
+		return nil, fmt.Errorf("relation property not found in entity %s", box.entity.name)
+	}
+	return linkBox, nil
+}
+
+// PutLink inserts the link, or updates the existing link between the same source and target objects (the ID of the
+// given object is set to the one of the existing link). Related objects are put as well, as with Box.Put(), but they
+// must already have an ID, i.e. be put before.
+func (lb *LinkBox) PutLink(link interface{}) (id uint64, err error) {
+	err = lb.ObjectBox.RunInWriteTx(func() error {
+		var fbb = flatbuffers.NewBuilder(512)
+		if err := lb.entity.binding.Flatten(link, fbb, 0); err != nil {
+			return err
+		}
+		fbb.Finish(fbb.EndObject())
+
+		var table = rawTable(fbb.FinishedBytes())
+		sourceId, err := lb.readLinkId(lb.sourceProp, table)
+		if err != nil {
+			return err
+		}
+		targetId, err := lb.readLinkId(lb.targetProp, table)
+		if err != nil {
+			return err
+		}
+
+		existingIds, err := lb.linkIds(sourceId, targetId)
+		if err != nil {
+			return err
+		} else if len(existingIds) > 0 {
+			if err := lb.entity.binding.SetId(link, existingIds[0]); err != nil {
+				return err
+			}
+		}
+
+		id, err = lb.put(link, true, cPutModePut)
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// readLinkId returns the ID of the related object stored in the given relation property of the link
+func (lb *LinkBox) readLinkId(prop *property, table *flatbuffers.Table) (uint64, error) {
+	value, err := prop.read(table)
+	if err != nil {
+		return 0, err
+	} else if value == nil || value.(uint64) == 0 {
+		return 0, fmt.Errorf("link relation %s is not set; put the related object first", prop.name)
+	}
+	return value.(uint64), nil
+}
+
+// GetLink returns the link between the given source and target objects, or nil if they aren't linked
+func (lb *LinkBox) GetLink(sourceId, targetId uint64) (link interface{}, err error) {
+	query, err := lb.pairQuery(sourceId, targetId)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+	return query.FindFirst()
+}
+
+// RemoveLink removes the link between the given source and target objects; returns false if they weren't linked
+func (lb *LinkBox) RemoveLink(sourceId, targetId uint64) (removed bool, err error) {
+	query, err := lb.pairQuery(sourceId, targetId)
+	if err != nil {
+		return false, err
+	}
+	defer query.Close()
+
+	count, err := query.Remove()
+	return count > 0, err
+}
+
+// FindBySource returns the links of the given source object, optionally matching additional conditions on the link,
+// as a slice of the link entity type
+func (lb *LinkBox) FindBySource(sourceId uint64, conditions ...Condition) (links interface{}, err error) {
+	return lb.find(lb.source.Equals(sourceId), conditions)
+}
+
+// FindByTarget returns the links of the given target object, optionally matching additional conditions on the link,
+// as a slice of the link entity type
+func (lb *LinkBox) FindByTarget(targetId uint64, conditions ...Condition) (links interface{}, err error) {
+	return lb.find(lb.target.Equals(targetId), conditions)
+}
+
+func (lb *LinkBox) find(condition Condition, conditions []Condition) (interface{}, error) {
+	query, err := lb.QueryOrError(append([]Condition{condition}, conditions...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+	return query.Find()
+}
+
+// TargetIds returns IDs of the target objects linked to the given source object
+func (lb *LinkBox) TargetIds(sourceId uint64, conditions ...Condition) ([]uint64, error) {
+	return lb.relatedIds(lb.source.Equals(sourceId), conditions, lb.targetProp)
+}
+
+// SourceIds returns IDs of the source objects linked to the given target object
+func (lb *LinkBox) SourceIds(targetId uint64, conditions ...Condition) ([]uint64, error) {
+	return lb.relatedIds(lb.target.Equals(targetId), conditions, lb.sourceProp)
+}
+
+// relatedIds reads the IDs stored in the given relation property of the links matching the conditions
+func (lb *LinkBox) relatedIds(condition Condition, conditions []Condition, prop *property) ([]uint64, error) {
+	query, err := lb.QueryOrError(append([]Condition{condition}, conditions...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	var ids []uint64
+	var errRead error
+	err = query.FindRaw(func(bytes []byte) bool {
+		value, err := prop.read(rawTable(bytes))
+		if err != nil {
+			errRead = err
+			return false
+		} else if value != nil {
+			ids = append(ids, value.(uint64))
+		}
+		return true
+	})
+	if err == nil {
+		err = errRead
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// linkIds returns IDs of the links between the given source and target objects
+func (lb *LinkBox) linkIds(sourceId, targetId uint64) ([]uint64, error) {
+	query, err := lb.pairQuery(sourceId, targetId)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+	return query.FindIds()
+}
+
+// pairQuery creates a query for the links between the given source and target objects
+func (lb *LinkBox) pairQuery(sourceId, targetId uint64) (*Query, error) {
+	return lb.QueryOrError(lb.source.Equals(sourceId), lb.target.Equals(targetId))
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// LogLevel is the severity of a message passed to a Logger
+type LogLevel int
+
+const (
+	// LogLevelDebug is used for diagnostic messages
+	LogLevelDebug LogLevel = iota
+
+	// LogLevelInfo is used for informational messages
+	LogLevelInfo
+
+	// LogLevelWarn is used for unexpected situations which don't cause an operation to fail
+	LogLevelWarn
+
+	// LogLevelError is used for errors which can't be returned to the caller, e.g. in finalizers
+	LogLevelError
+)
+
+// String returns the level name, e.g. "WARN"
+func (level LogLevel) String() string {
+	switch level {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(level))
+}
+
+// Logger receives internal messages of ObjectBox that can't be returned as errors, e.g. failures in finalizers.
+// Fields are alternating key/value pairs, e.g. "entity", "Task", "error", err, "code", 10001.
+// Loggers must be safe for concurrent use. See SetLogger() and Builder.Logger().
+type Logger interface {
+	Log(level LogLevel, message string, fields ...interface{})
+}
+
+// stdoutLogger is the default Logger, printing the messages to the standard output
+type stdoutLogger struct{}
+
+func (stdoutLogger) Log(level LogLevel, message string, fields ...interface{}) {
+	var sb strings.Builder
+	sb.WriteString(level.String())
+	sb.WriteString(" ObjectBox: ")
+	sb.WriteString(message)
+	for i := 0; i+1 < len(fields); i += 2 {
+		fmt.Fprintf(&sb, " %v=%v", fields[i], fields[i+1])
+	}
+	fmt.Println(sb.String())
+}
+
+var packageLogger Logger = stdoutLogger{}
+var packageLoggerMutex sync.Mutex
+
+// SetLogger configures the Logger for messages which aren't related to a specific store and for stores without
+// their own Builder.Logger(). Passing nil restores the default, which prints to the standard output.
+func SetLogger(logger Logger) {
+	if logger == nil {
+		logger = stdoutLogger{}
+	}
+
+	packageLoggerMutex.Lock()
+	packageLogger = logger
+	packageLoggerMutex.Unlock()
+}
+
+// defaultLogger returns the package-level logger configured using SetLogger
+func defaultLogger() Logger {
+	packageLoggerMutex.Lock()
+	defer packageLoggerMutex.Unlock()
+	return packageLogger
+}
+
+// logger returns the logger configured for this store, falling back to the package-level one
+func (ob *ObjectBox) logger() Logger {
+	if ob != nil && ob.options.logger != nil {
+		return ob.options.logger
+	}
+	return defaultLogger()
+}
+
+// logError logs the error, including its native error code (if available), with the given additional fields
+func (ob *ObjectBox) logError(message string, err error, fields ...interface{}) {
+	fields = append(fields, "error", err)
+	if obxErr, ok := err.(*Error); ok {
+		fields = append(fields, "code", obxErr.Code())
+	}
+	ob.logger().Log(LogLevelError, message, fields...)
+}
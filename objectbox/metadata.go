@@ -0,0 +1,134 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EntityMetadata describes an entity of the model the store was opened with, see ObjectBox.EntityMetadata()
+type EntityMetadata struct {
+	Id   TypeId
+	Name string
+
+	// Properties in the order they were defined in the model
+	Properties []PropertyMetadata
+}
+
+// PropertyMetadata describes a single property of an entity, see EntityMetadata
+type PropertyMetadata struct {
+	Id   TypeId
+	Name string
+
+	// Type is the stored property type as used in the model, e.g. 9 for strings; see TypeName()
+	Type int
+
+	// Flags is a bit combination of the property flags as used in the model, e.g. 1 for the ID property
+	Flags int
+}
+
+// TypeName returns a readable name of the property type, e.g. "String" or "Date"
+func (prop PropertyMetadata) TypeName() string {
+	switch prop.Type {
+	case C.OBXPropertyType_Bool:
+		return "Bool"
+	case C.OBXPropertyType_Byte:
+		return "Byte"
+	case C.OBXPropertyType_Short:
+		return "Short"
+	case C.OBXPropertyType_Char:
+		return "Char"
+	case C.OBXPropertyType_Int:
+		return "Int"
+	case C.OBXPropertyType_Long:
+		return "Long"
+	case C.OBXPropertyType_Float:
+		return "Float"
+	case C.OBXPropertyType_Double:
+		return "Double"
+	case C.OBXPropertyType_String:
+		return "String"
+	case C.OBXPropertyType_Date:
+		return "Date"
+	case C.OBXPropertyType_Relation:
+		return "Relation"
+	case C.OBXPropertyType_DateNano:
+		return "DateNano"
+	case C.OBXPropertyType_Flex:
+		return "Flex"
+	case C.OBXPropertyType_ByteVector:
+		return "ByteVector"
+	case C.OBXPropertyType_StringVector:
+		return "StringVector"
+	}
+	return fmt.Sprintf("Unknown(%d)", prop.Type)
+}
+
+// IsId returns true if this is the ID property of the entity
+func (prop PropertyMetadata) IsId() bool {
+	return prop.Flags&C.OBXPropertyFlags_ID != 0
+}
+
+// IsIndexed returns true if the property has a (value or hash) index
+func (prop PropertyMetadata) IsIndexed() bool {
+	return prop.Flags&(C.OBXPropertyFlags_INDEXED|C.OBXPropertyFlags_INDEX_HASH|C.OBXPropertyFlags_INDEX_HASH64) != 0
+}
+
+// IsUnique returns true if the property values must be unique
+func (prop PropertyMetadata) IsUnique() bool {
+	return prop.Flags&C.OBXPropertyFlags_UNIQUE != 0
+}
+
+// EntityNames returns the names of all entities in the model, sorted alphabetically
+func (ob *ObjectBox) EntityNames() []string {
+	var names = make([]string, 0, len(ob.entitiesByName))
+	for name := range ob.entitiesByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EntityMetadata returns the description of the entity with the given name, as defined in the model.
+// This allows generic code (e.g. admin endpoints or export tools) to work with entities without hardcoding them.
+func (ob *ObjectBox) EntityMetadata(name string) (*EntityMetadata, error) {
+	var entity = ob.entitiesByName[name]
+	if entity == nil {
+		return nil, fmt.Errorf("entity %s is not part of the model", name)
+	}
+
+	var metadata = &EntityMetadata{
+		Id:         entity.id,
+		Name:       entity.name,
+		Properties: make([]PropertyMetadata, len(entity.properties)),
+	}
+	for i, prop := range entity.properties {
+		metadata.Properties[i] = PropertyMetadata{
+			Id:    prop.id,
+			Name:  prop.name,
+			Type:  prop.propertyType,
+			Flags: prop.flags,
+		}
+	}
+	return metadata, nil
+}
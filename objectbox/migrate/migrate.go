@@ -0,0 +1,108 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package migrate provides tools for data migrations, e.g. to backfill new properties of large numbers of objects.
+// Use it from a migration step (see objectbox.Builder.MigrateTo) or as a standalone tool.
+package migrate
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// Progress describes the state of a running BatchUpdate
+type Progress struct {
+	// Total is the number of objects matching the query when the update started
+	Total uint64
+
+	// Processed is the number of objects passed to the update function so far
+	Processed uint64
+
+	// Changed is the number of objects stored again because the update function reported a change
+	Changed uint64
+}
+
+// BatchUpdate passes each object matching the query to fn and puts the objects for which fn returns true.
+// The objects are processed in batches of the given size, each in its own write transaction, so the memory usage
+// stays bounded and other writers get their turn in between; if a batch fails, the previous batches stay committed.
+// The set of matching objects is determined at the start; objects removed in the meantime are skipped.
+// Returns the final progress.
+func BatchUpdate(box *objectbox.Box, query *objectbox.Query, batchSize uint64,
+	fn func(object interface{}) (changed bool)) (Progress, error) {
+	return BatchUpdateWithProgress(box, query, batchSize, fn, nil)
+}
+
+// BatchUpdateWithProgress is like BatchUpdate but calls progress after each committed batch, e.g. to log it
+func BatchUpdateWithProgress(box *objectbox.Box, query *objectbox.Query, batchSize uint64,
+	fn func(object interface{}) (changed bool), progress func(Progress)) (Progress, error) {
+	var result Progress
+	if batchSize == 0 {
+		return result, errors.New("batch size must be greater than zero")
+	} else if fn == nil {
+		return result, errors.New("update function is nil")
+	}
+
+	ids, err := query.FindIds()
+	if err != nil {
+		return result, err
+	}
+	result.Total = uint64(len(ids))
+
+	for len(ids) > 0 {
+		var batch = ids
+		if uint64(len(batch)) > batchSize {
+			batch = batch[:batchSize]
+		}
+		ids = ids[len(batch):]
+
+		var processed, changed uint64
+		err = box.ObjectBox.RunInWriteTx(func() error {
+			objects, err := box.GetManyExisting(batch...)
+			if err != nil {
+				return err
+			}
+
+			var slice = reflect.ValueOf(objects)
+			var toPut = reflect.MakeSlice(slice.Type(), 0, slice.Len())
+			for i := 0; i < slice.Len(); i++ {
+				processed++
+				if fn(slice.Index(i).Interface()) {
+					toPut = reflect.Append(toPut, slice.Index(i))
+				}
+			}
+
+			if toPut.Len() > 0 {
+				if _, err := box.PutMany(toPut.Interface()); err != nil {
+					return err
+				}
+			}
+			changed = uint64(toPut.Len())
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Processed += processed
+		result.Changed += changed
+		if progress != nil {
+			progress(result)
+		}
+	}
+	return result, nil
+}
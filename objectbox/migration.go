@@ -0,0 +1,228 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// MigrationTx gives access to the store inside a migration step, see Builder.MigrateTo()
+type MigrationTx struct {
+	ob *ObjectBox
+
+	// From is the schema version before this step
+	From int
+
+	// To is the schema version after this step
+	To int
+}
+
+// ObjectBox returns the store, e.g. to get boxes using the generated BoxFor* functions inside the migration step
+func (tx *MigrationTx) ObjectBox() *ObjectBox {
+	return tx.ob
+}
+
+// Box returns the box for the given entity ID, like ObjectBox.InternalBox() but returning an error instead of panicking
+func (tx *MigrationTx) Box(entityId TypeId) (*Box, error) {
+	return tx.ob.box(entityId)
+}
+
+// migrationStep holds a single step added by Builder.MigrateTo()
+type migrationStep struct {
+	version int
+	fn      func(tx *MigrationTx) error
+}
+
+// MigrateTo adds a data migration step, e.g. to backfill a new property or to move objects to a new entity.
+// When the store is opened, the steps with a version higher than the stored schema version run once, in the order of
+// their versions, each inside its own write transaction. The new schema version is stored in the same transaction, so
+// a step is either applied completely, including the version update, or not at all.
+// If a step fails, its transaction is rolled back and opening the store fails with the step's error.
+//
+// Versions must be positive and added in an ascending order. The schema version is stored in the database, in the
+// entity configured by SchemaVersionProperty(), which is required when adding migration steps.
+// See also ObjectBox.SchemaVersion().
+func (builder *Builder) MigrateTo(version int, fn func(tx *MigrationTx) error) *Builder {
+	if builder.Error != nil {
+		return builder
+	}
+
+	if version <= 0 {
+		builder.Error = fmt.Errorf("migration version must be positive, got %d", version)
+	} else if count := len(builder.migrations); count > 0 && builder.migrations[count-1].version >= version {
+		builder.Error = fmt.Errorf("migration version %d must be higher than the previous one, %d",
+			version, builder.migrations[count-1].version)
+	} else if fn == nil {
+		builder.Error = fmt.Errorf("migration %d: function is nil", version)
+	} else {
+		builder.migrations = append(builder.migrations, migrationStep{version: version, fn: fn})
+	}
+	return builder
+}
+
+// SchemaVersionProperty configures where the schema version used by MigrateTo() is stored: an int64 property of a
+// dedicated entity (see "Dedicated entities" in the package documentation), e.g.:
+//
+//	type SchemaVersion struct {
+//		Id      uint64
+//		Version int64
+//	}
+//
+// and configured using the generated property: builder.SchemaVersionProperty(SchemaVersion_.Version).
+// The version is kept in a single object of that entity, which therefore must not have any other properties;
+// opening the store fails if it does or if the entity contains more than one object.
+// Being a part of the database, the version is included in backups, compacted copies and dumps of the entity.
+func (builder *Builder) SchemaVersionProperty(version *PropertyInt64) *Builder {
+	if builder.Error != nil {
+		return builder
+	}
+
+	if version == nil || version.BaseProperty == nil {
+		builder.Error = errors.New("schema version property is not defined")
+	} else {
+		builder.schemaVersion = version
+	}
+	return builder
+}
+
+// SchemaVersion returns the version of the last migration step applied to the store, see Builder.MigrateTo().
+// Returns 0 if no migration step has been applied yet or if Builder.SchemaVersionProperty() wasn't configured.
+func (ob *ObjectBox) SchemaVersion() int {
+	return ob.schemaVersion
+}
+
+// schemaVersionStore reads and writes the schema version stored in the entity configured by SchemaVersionProperty()
+type schemaVersionStore struct {
+	entity   *dedicatedEntity
+	version  *property
+	objectId uint64 // ID of the object holding the version; 0 if it hasn't been stored yet
+}
+
+func (ob *ObjectBox) newSchemaVersionStore(version *PropertyInt64) (*schemaVersionStore, error) {
+	entity, err := ob.dedicatedEntity(version.entityId())
+	if err != nil {
+		return nil, err
+	}
+
+	var store = &schemaVersionStore{entity: entity, version: entity.property(version.BaseProperty)}
+	if store.version == nil || store.version.propertyType != C.OBXPropertyType_Long {
+		return nil, fmt.Errorf("schema version property %d must be an int64 property of entity %s",
+			version.propertyId(), entity.box.entity.name)
+	}
+
+	// the whole object is overwritten by write() so the entity must not hold any other data
+	for _, prop := range entity.box.entity.properties {
+		if prop != entity.id && prop != store.version {
+			return nil, fmt.Errorf("entity %s storing the schema version must only have the ID and the version "+
+				"properties, found %s", entity.box.entity.name, prop.name)
+		}
+	}
+	return store, nil
+}
+
+// read returns the stored schema version, or 0 if none is stored yet.
+// Fails if there's more than one object as it wouldn't be clear which one holds the version.
+func (store *schemaVersionStore) read() (version int, err error) {
+	err = store.entity.box.ObjectBox.RunInReadTx(func() error {
+		return store.entity.visit(nil, func(table *flatbuffers.Table) (bool, error) {
+			if store.objectId != 0 {
+				return false, fmt.Errorf("entity %s storing the schema version must contain a single object",
+					store.entity.box.entity.name)
+			}
+
+			id, err := store.entity.id.read(table)
+			if err != nil {
+				return false, err
+			}
+			value, err := store.version.read(table)
+			if err != nil {
+				return false, err
+			}
+
+			store.objectId = id.(uint64)
+			if value != nil {
+				version = int(int64(value.(uint64)))
+			}
+			return true, nil
+		})
+	})
+	return version, err
+}
+
+// write stores the given schema version; call it inside the write transaction running the migration step
+func (store *schemaVersionStore) write(version int) error {
+	id, err := store.entity.put(store.objectId, map[TypeId]interface{}{
+		store.version.id: uint64(version),
+	}, 64, cPutModePut)
+	if err != nil {
+		return err
+	}
+
+	store.objectId = id
+	return nil
+}
+
+// migrate reads the stored schema version and runs the pending steps
+func (ob *ObjectBox) migrate(versionProperty *PropertyInt64, steps []migrationStep, readOnly bool) error {
+	if versionProperty == nil {
+		if len(steps) > 0 {
+			return errors.New("migration steps require Builder.SchemaVersionProperty() to store the schema version")
+		}
+		return nil
+	}
+
+	store, err := ob.newSchemaVersionStore(versionProperty)
+	if err != nil {
+		return err
+	}
+
+	if ob.schemaVersion, err = store.read(); err != nil {
+		return fmt.Errorf("can't read the schema version: %s", err)
+	}
+
+	for _, step := range steps {
+		if step.version <= ob.schemaVersion {
+			continue
+		} else if readOnly {
+			return fmt.Errorf("can't run migration %d on a read-only store (schema version %d)",
+				step.version, ob.schemaVersion)
+		}
+
+		var tx = &MigrationTx{ob: ob, From: ob.schemaVersion, To: step.version}
+		var objectId = store.objectId
+		if err := ob.RunInWriteTx(func() error {
+			if err := step.fn(tx); err != nil {
+				return err
+			}
+			return store.write(step.version)
+		}); err != nil {
+			store.objectId = objectId // the object may not have been stored after all
+			return fmt.Errorf("migration %d failed: %s", step.version, err)
+		}
+
+		ob.schemaVersion = step.version
+	}
+	return nil
+}
@@ -79,6 +79,9 @@ type Model struct {
 	lastRelationUid uint64
 
 	generatorVersion int
+
+	// minLibVersion is the lowest native library version the model can be used with (see MinLibVersion)
+	minLibVersion Version
 }
 
 // NewModel creates a model
@@ -105,6 +108,17 @@ func (model *Model) GeneratorVersion(version int) {
 	model.generatorVersion = version
 }
 
+// MinLibVersion declares the lowest version of the ObjectBox native library the model can be used with, e.g. because
+// it uses property types or flags introduced in that version. Opening a store with this model fails with a descriptive
+// error if the loaded library is older.
+func (model *Model) MinLibVersion(version Version) {
+	if model.Error != nil {
+		return
+	}
+
+	model.minLibVersion = version
+}
+
 // LastEntityId declares an entity with the highest ID.
 // Used as a compatibility check when opening DB with an older model version.
 func (model *Model) LastEntityId(id TypeId, uid uint64) {
@@ -183,6 +197,11 @@ func (model *Model) Relation(relationId TypeId, relationUid uint64, targetEntity
 	})
 
 	model.currentEntity.hasRelations = true
+	model.currentEntity.relations = append(model.currentEntity.relations, &RelationToMany{
+		Id:     relationId,
+		Source: &Entity{Id: model.currentEntity.id},
+		Target: &Entity{Id: targetEntityId},
+	})
 }
 
 // EntityLastPropertyId declares a property with the highest ID.
@@ -208,6 +227,14 @@ func (model *Model) Property(name string, propertyType int, id TypeId, uid uint6
 	model.Error = cCall(func() C.obx_err {
 		return C.obx_model_property(model.cModel, cname, C.OBXPropertyType(propertyType), C.obx_schema_id(id), C.obx_uid(uid))
 	})
+
+	if model.Error == nil && model.currentEntity != nil {
+		model.currentEntity.properties = append(model.currentEntity.properties, &property{
+			id:           id,
+			name:         name,
+			propertyType: propertyType,
+		})
+	}
 }
 
 // PropertyFlags configures type and other information about the property
@@ -218,6 +245,10 @@ func (model *Model) PropertyFlags(propertyFlags int) {
 	model.Error = cCall(func() C.obx_err {
 		return C.obx_model_property_flags(model.cModel, C.OBXPropertyFlags(propertyFlags))
 	})
+
+	if model.Error == nil && model.currentEntity != nil && len(model.currentEntity.properties) > 0 {
+		model.currentEntity.properties[len(model.currentEntity.properties)-1].flags = propertyFlags
+	}
 }
 
 // PropertyIndex creates a new index on the property
@@ -313,5 +344,10 @@ func (model *Model) validate() error {
 		return fmt.Errorf("last entity ID/UID is missing")
 	}
 
+	if VersionLib().LessThan(model.minLibVersion) {
+		return fmt.Errorf("the model requires ObjectBox C library version %v or newer but the loaded version is %v "+
+			"- please update the library, see https://github.com/objectbox/objectbox-go", model.minLibVersion, VersionLib())
+	}
+
 	return nil
 }
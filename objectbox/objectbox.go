@@ -17,7 +17,6 @@
 package objectbox
 
 /*
-#cgo LDFLAGS: -lobjectbox
 #include <stdlib.h>
 #include "objectbox.h"
 */
@@ -29,6 +28,7 @@ import (
 	"runtime"
 	"strconv"
 	"sync"
+	"time"
 )
 
 const (
@@ -80,25 +80,71 @@ type ObjectBox struct {
 	boxesMutex     sync.Mutex
 	options        options
 	syncClient     *SyncClient
+	directory      string
+	storeOptions   storeOptions
+	activity       activity
+	writeQueue     *writeQueue
+	txListeners    txListeners
+	schemaVersion  int
+	softDelete     map[TypeId]*PropertyInt64
 }
 
 type options struct {
 	asyncTimeout uint
+	retryPolicy  RetryPolicy
+	observer     OperationObserver
+	logger       Logger
 }
 
 // constant during runtime so no need to call this each time it's necessary
 var supportsResultArray = bool(C.obx_has_feature(C.OBXFeature_ResultArray))
 
-// Close fully closes the database and frees resources
+// Close fully closes the database and frees resources.
+// Operations started before (e.g. running in other goroutines) are waited for, all subsequent ones fail with
+// ErrStoreClosed, except for those inside a transaction that is still running. Don't call Close() from inside a
+// transaction; it would wait for itself indefinitely.
 func (ob *ObjectBox) Close() {
+	_ = ob.CloseWithTimeout(0)
+}
+
+// CloseWithTimeout is like Close() but waits at most the given time for the operations in progress to finish;
+// 0 means no timeout. Returns an error if they didn't finish in time, in which case the store stays open (but
+// rejecting new operations) and you can call CloseWithTimeout() or Close() again later.
+func (ob *ObjectBox) CloseWithTimeout(timeout time.Duration) error {
+	ob.activity.mutex.Lock()
+	ob.activity.closing = true
+	if ob.activity.idle == nil {
+		ob.activity.idle = make(chan struct{})
+		if ob.activity.active == 0 {
+			close(ob.activity.idle)
+		}
+	}
+	var idle = ob.activity.idle
+	var active = ob.activity.active
+	ob.activity.mutex.Unlock()
+
+	if timeout > 0 {
+		select {
+		case <-idle:
+		case <-time.After(timeout):
+			return fmt.Errorf("can't close the store: %d operation(s) still in progress after %v", active, timeout)
+		}
+	} else {
+		<-idle
+	}
+
+	ob.activity.mutex.Lock()
 	storeToClose := ob.store
 	ob.store = nil
-	if ob.syncClient != nil {
-		_ = ob.syncClient.Close()
-	}
+	ob.activity.mutex.Unlock()
+
 	if storeToClose != nil {
+		if ob.syncClient != nil {
+			_ = ob.syncClient.Close()
+		}
 		C.obx_store_close(storeToClose)
 	}
+	return nil
 }
 
 // RunInReadTx executes the given function inside a read transaction.
@@ -120,21 +166,96 @@ func (ob *ObjectBox) RunInWriteTx(fn func() error) error {
 	return ob.runInTxn(false, fn)
 }
 
-func (ob *ObjectBox) runInTxn(readOnly bool, fn func() error) (err error) {
+// ReadTx gives access to the store inside View(); all reads done through it see the same, consistent data snapshot
+type ReadTx struct {
+	ob *ObjectBox
+}
+
+// ObjectBox returns the store, e.g. to get boxes using the generated BoxFor* functions inside View()
+func (tx *ReadTx) ObjectBox() *ObjectBox {
+	return tx.ob
+}
+
+// Box returns the box for the given entity ID, like ObjectBox.InternalBox() but returning an error instead of panicking
+func (tx *ReadTx) Box(entityId TypeId) (*Box, error) {
+	return tx.ob.box(entityId)
+}
+
+// View executes the given function inside a single read transaction, giving a consistent view of the data:
+// all boxes and queries used inside `fn` (e.g. multiple counts for a report) read the same snapshot,
+// not seeing changes committed by other transactions in the meantime.
+// The same restrictions as for RunInReadTx apply, i.e. reads done in other goroutines are not part of the transaction.
+// The error returned by your callback is passed-through as the output error.
+func (ob *ObjectBox) View(fn func(tx *ReadTx) error) error {
+	var tx = &ReadTx{ob: ob}
+	return ob.RunInReadTx(func() error {
+		return fn(tx)
+	})
+}
+
+func (ob *ObjectBox) runInTxn(readOnly bool, fn func() error) error {
+	return ob.guard(func() error {
+		return ob.txn(readOnly, fn)
+	})
+}
+
+func (ob *ObjectBox) txn(readOnly bool, fn func() error) (err error) {
+	if ob.options.observer != nil {
+		var operation = OperationWriteTx
+		if readOnly {
+			operation = OperationReadTx
+		}
+		var obs = ob.observe(operation, nil)
+		defer func() { obs.done(0, err) }()
+	}
+
+	// notify transaction listeners once the transaction is closed and the write queue released
+	var txId uint64
+	var committed bool
+	if !readOnly {
+		defer func() {
+			if txId == 0 {
+				return
+			} else if committed {
+				ob.txListeners.notifyWriteTx(txId, nil)
+			} else if err != nil {
+				ob.txListeners.notifyWriteTx(txId, err)
+			} else {
+				ob.txListeners.notifyWriteTx(txId, errors.New("transaction aborted by a panic"))
+			}
+		}()
+	}
+
 	// NOTE if runtime.LockOSThread() is about to be removed, evaluate use of createError() inside transactions
 	runtime.LockOSThread()
 
-	var cTxn *C.OBX_txn
-	if readOnly {
-		cTxn = C.obx_txn_read(ob.store)
-	} else {
-		cTxn = C.obx_txn_write(ob.store)
+	if !readOnly {
+		if err := ob.writeQueue.acquire(); err != nil {
+			runtime.UnlockOSThread()
+			return err
+		}
+		defer ob.writeQueue.release()
 	}
 
-	if cTxn == nil {
+	var cTxn *C.OBX_txn
+	for attempt := uint(1); ; attempt++ {
+		if readOnly {
+			cTxn = C.obx_txn_read(ob.store)
+		} else {
+			cTxn = C.obx_txn_write(ob.store)
+		}
+
+		if cTxn != nil {
+			break
+		}
+
 		err = createError()
-		runtime.UnlockOSThread()
-		return err
+		if delay, retry := ob.options.retryPolicy.retryDelay(attempt, err); retry {
+			time.Sleep(delay)
+		} else {
+			runtime.UnlockOSThread()
+			return err
+		}
 	}
 
 	// Defer to ensure a TX is ALWAYS closed, even in a panic
@@ -152,6 +273,16 @@ func (ob *ObjectBox) runInTxn(readOnly bool, fn func() error) (err error) {
 		runtime.UnlockOSThread()
 	}()
 
+	// operations of this store inside fn may proceed even if Close() is called in the meantime
+	defer leaveTx(ob.enterTx())
+
+	if !readOnly {
+		var previousTxId C.uint64_t
+		if txId, previousTxId = ob.txListeners.beginWriteTx(); txId != 0 {
+			defer ob.txListeners.endWriteTx(previousTxId)
+		}
+	}
+
 	err = fn()
 
 	if !readOnly && err == nil {
@@ -159,6 +290,8 @@ func (ob *ObjectBox) runInTxn(readOnly bool, fn func() error) (err error) {
 		cTxn = nil
 		if rc := C.obx_txn_success(ptr); rc != 0 {
 			err = createError()
+		} else {
+			committed = true
 		}
 	}
 
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package objectboxtest provides helpers for using ObjectBox in unit tests.
+package objectboxtest
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// NewTestStore opens a new, empty store for the given model. Call the returned function (e.g. using defer) when the
+// test finishes to close the store. An in-memory database is used if the loaded ObjectBox library supports it,
+// otherwise a temporary directory is created (and removed by the returned function).
+//
+// Pass the result of the generated function ObjectBoxModel as the model:
+//
+//	ob, cleanup := objectboxtest.NewTestStore(t, ObjectBoxModel())
+//	defer cleanup()
+func NewTestStore(t testing.TB, model *objectbox.Model) (*objectbox.ObjectBox, func()) {
+	t.Helper()
+
+	var builder = objectbox.NewBuilder().Model(model)
+	var dir string
+
+	if objectbox.InMemoryIsAvailable() {
+		// the test name keeps stores of concurrently running (parallel) tests separate
+		builder.InMemory(t.Name())
+	} else {
+		var err error
+		dir, err = ioutil.TempDir("", "objectbox-test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		builder.Directory(dir)
+	}
+
+	ob, err := builder.BuildOrError()
+	if err != nil {
+		if dir != "" {
+			os.RemoveAll(dir)
+		}
+		t.Fatalf("could not open test store: %s", err)
+	}
+
+	return ob, func() {
+		ob.Close()
+		if dir != "" {
+			os.RemoveAll(dir)
+		}
+	}
+}
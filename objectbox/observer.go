@@ -0,0 +1,116 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"reflect"
+	"time"
+)
+
+// Operation identifies the kind of an operation reported to an OperationObserver; usable e.g. as a metrics label
+type Operation string
+
+const (
+	// OperationPut - objects were inserted or updated; reported per Put and per PutMany chunk
+	OperationPut Operation = "put"
+
+	// OperationGet - objects were read by ID (Box.Get, GetMany, GetManyExisting, GetAll)
+	OperationGet Operation = "get"
+
+	// OperationQuery - a query was executed (Query.Find, FindIds, Count)
+	OperationQuery Operation = "query"
+
+	// OperationRemove - objects were removed (Box.Remove*, Query.Remove)
+	OperationRemove Operation = "remove"
+
+	// OperationReadTx - a read transaction finished, including any nested ones
+	OperationReadTx Operation = "readTx"
+
+	// OperationWriteTx - a write transaction was committed or aborted, including any nested ones
+	OperationWriteTx Operation = "writeTx"
+)
+
+// OperationEvent describes a finished operation, see OperationObserver
+type OperationEvent struct {
+	Operation Operation
+
+	// Entity is the name of the entity the operation was executed on; empty for transactions
+	Entity string
+
+	// Count is the number of objects put, read or removed (or counted by Query.Count)
+	Count int
+
+	// Bytes is the serialized size of the objects written by OperationPut
+	Bytes int
+
+	Duration time.Duration
+
+	// Err is the error returned by the operation, if any
+	Err error
+}
+
+// OperationObserver receives an event for each finished operation, see Builder.Observer().
+// It's called synchronously by the goroutine executing the operation so it must return quickly, e.g. just update
+// counters/histograms, and it must be safe for concurrent use.
+type OperationObserver func(event OperationEvent)
+
+// observation measures a single operation; all its methods are no-ops on a nil observation (no observer configured)
+type observation struct {
+	observer OperationObserver
+	event    OperationEvent
+	start    time.Time
+}
+
+// observe starts measuring an operation; returns nil if there's no observer configured
+func (ob *ObjectBox) observe(operation Operation, entity *entity) *observation {
+	if ob.options.observer == nil {
+		return nil
+	}
+
+	var obs = &observation{observer: ob.options.observer, start: time.Now()}
+	obs.event.Operation = operation
+	if entity != nil {
+		obs.event.Entity = entity.name
+	}
+	return obs
+}
+
+func (obs *observation) addBytes(bytes int) {
+	if obs != nil {
+		obs.event.Bytes += bytes
+	}
+}
+
+// done reports the finished operation to the observer
+func (obs *observation) done(count int, err error) {
+	if obs == nil {
+		return
+	}
+
+	obs.event.Count = count
+	obs.event.Duration = time.Since(obs.start)
+	obs.event.Err = err
+	obs.observer(obs.event)
+}
+
+// sliceLen returns the number of objects in a slice created by ObjectBinding.MakeSlice(), or 0 if it's nil
+func sliceLen(slice interface{}) int {
+	if slice == nil {
+		return 0
+	}
+	return reflect.ValueOf(slice).Len()
+}
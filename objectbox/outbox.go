@@ -0,0 +1,189 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// Outbox implements the transactional outbox pattern: messages are stored in the same write transaction as the data
+// they describe, and published by a consumer afterwards, which removes (acknowledges) them once they're delivered.
+// Thus, a message is only published if the data was actually stored, and isn't lost if the publishing fails.
+//
+// The messages are stored in a dedicated entity (see "Dedicated entities" in the package documentation), e.g.:
+//
+//	type OutboxMessage struct {
+//		Id        uint64
+//		Payload   []byte
+//		CreatedAt time.Time `objectbox:"date"`
+//	}
+//
+// and the outbox is created using the generated properties:
+//
+//	outbox, err := ob.NewOutbox(OutboxMessage_.Payload, OutboxMessage_.CreatedAt)
+type Outbox struct {
+	entity    *dedicatedEntity
+	payload   *property
+	createdAt *property
+}
+
+// OutboxMessage is a message read from the outbox by Outbox.Poll()
+type OutboxMessage struct {
+	Id        uint64
+	Payload   []byte
+	CreatedAt time.Time // zero if the outbox doesn't store the creation time
+}
+
+// NewOutbox creates an outbox storing messages in the entity the given byte vector property belongs to.
+// The optional createdAt property (an int64 or a date field) receives the time of creation in milliseconds since the
+// Unix epoch; pass nil if you don't need it.
+func (ob *ObjectBox) NewOutbox(payload *PropertyByteVector, createdAt *PropertyInt64) (*Outbox, error) {
+	if payload == nil || payload.BaseProperty == nil {
+		return nil, errors.New("outbox payload property is not defined")
+	}
+
+	entity, err := ob.dedicatedEntity(payload.entityId())
+	if err != nil {
+		return nil, err
+	}
+
+	var outbox = &Outbox{entity: entity, payload: entity.property(payload.BaseProperty)}
+	if outbox.payload == nil || outbox.payload.propertyType != C.OBXPropertyType_ByteVector {
+		return nil, fmt.Errorf("outbox payload property %d must be a []byte property of entity %s",
+			payload.propertyId(), entity.box.entity.name)
+	}
+
+	if createdAt != nil && createdAt.BaseProperty != nil {
+		outbox.createdAt = entity.property(createdAt.BaseProperty)
+		if outbox.createdAt == nil {
+			return nil, fmt.Errorf("property %d doesn't belong to entity %s", createdAt.propertyId(),
+				entity.box.entity.name)
+		} else if outbox.createdAt.propertyType != C.OBXPropertyType_Date &&
+			outbox.createdAt.propertyType != C.OBXPropertyType_Long {
+			return nil, fmt.Errorf("outbox creation time property %s must be a date or int64", outbox.createdAt.name)
+		}
+	}
+
+	return outbox, nil
+}
+
+// Write runs fn in a write transaction and adds the given messages to the outbox in the same transaction.
+// If fn returns an error, the transaction is rolled back and no message is added.
+func (outbox *Outbox) Write(fn func() error, messages ...[]byte) error {
+	return outbox.entity.box.ObjectBox.RunInWriteTx(func() error {
+		if err := fn(); err != nil {
+			return err
+		}
+		return outbox.Add(messages...)
+	})
+}
+
+// Add stores the given messages in the outbox. Call it inside RunInWriteTx() to add the messages in the same
+// transaction as the related changes; outside of a transaction, the messages are added in a transaction of their own.
+func (outbox *Outbox) Add(messages ...[]byte) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	var values = make(map[TypeId]interface{}, 3)
+	if outbox.createdAt != nil {
+		values[outbox.createdAt.id] = uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	}
+
+	return outbox.entity.box.ObjectBox.RunInWriteTx(func() error {
+		for _, message := range messages {
+			values[outbox.payload.id] = message
+			if _, err := outbox.entity.put(0, values, len(message)+64, cPutModeInsert); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Poll returns up to max oldest messages from the outbox, in the order they were added.
+// The messages stay in the outbox until they're removed by Ack().
+func (outbox *Outbox) Poll(max int) (messages []OutboxMessage, err error) {
+	if max <= 0 {
+		return nil, errors.New("max must be greater than zero")
+	}
+
+	err = outbox.entity.box.ObjectBox.RunInReadTx(func() error {
+		return outbox.entity.visit(nil, func(table *flatbuffers.Table) (bool, error) {
+			message, err := outbox.read(table)
+			if err != nil {
+				return false, err
+			}
+			messages = append(messages, message)
+			return len(messages) < max, nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (outbox *Outbox) read(table *flatbuffers.Table) (message OutboxMessage, err error) {
+	id, err := outbox.entity.id.read(table)
+	if err != nil {
+		return message, err
+	}
+	message.Id = id.(uint64)
+
+	payload, err := outbox.payload.read(table)
+	if err != nil {
+		return message, err
+	} else if payload != nil {
+		// copy, the data is only valid during the transaction
+		message.Payload = append([]byte{}, payload.([]byte)...)
+	}
+
+	if outbox.createdAt != nil {
+		createdAt, err := outbox.createdAt.read(table)
+		if err != nil {
+			return message, err
+		} else if createdAt != nil && createdAt.(uint64) != 0 {
+			message.CreatedAt = time.Unix(0, int64(createdAt.(uint64))*int64(time.Millisecond))
+		}
+	}
+	return message, nil
+}
+
+// Ack removes the messages with the given IDs from the outbox, e.g. after they were published successfully.
+func (outbox *Outbox) Ack(ids ...uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := outbox.entity.box.removeIds(ids)
+	return err
+}
+
+// Pending returns the number of messages in the outbox
+func (outbox *Outbox) Pending() (uint64, error) {
+	return outbox.entity.box.Count()
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+// PropertyValue holds a new value of a single property, see Set() and Box.Patch()
+type PropertyValue struct {
+	property Property
+	value    interface{}
+}
+
+// Set creates a new value of the given property for Box.Patch().
+// The value must be of a Go type matching the stored property type, e.g. any integer type for integer properties,
+// time.Time or an integer for dates, []byte for byte vectors, etc. Passing nil removes the stored value.
+// Note: the value is stored as-is, i.e. custom converters defined on the entity field aren't applied.
+func Set(property Property, value interface{}) PropertyValue {
+	return PropertyValue{property: property, value: value}
+}
+
+// Patch updates only the given properties of the stored object with the given ID, keeping the other ones untouched.
+// Reading and writing happens in a single write transaction so concurrent changes of other properties aren't lost.
+// Returns false if there's no object with the given ID.
+// Note: the object isn't constructed, thus BeforePutHook isn't called and related objects aren't put.
+func (box *Box) Patch(id uint64, values ...PropertyValue) (found bool, err error) {
+	var changes = make(map[TypeId]interface{}, len(values))
+	for _, pv := range values {
+		if pv.property == nil {
+			return false, errors.New("property is not defined")
+		}
+
+		var prop = box.entity.property(pv.property.propertyId())
+		if pv.property.entityId() != box.entity.id || prop == nil {
+			return false, fmt.Errorf("property %d doesn't belong to entity %s", pv.property.propertyId(), box.entity.name)
+		} else if prop.flags&C.OBXPropertyFlags_ID != 0 {
+			return false, fmt.Errorf("the ID property %s can't be patched", prop.name)
+		}
+
+		value, err := prop.patchValue(pv.value)
+		if err != nil {
+			return false, err
+		}
+		changes[prop.id] = value
+	}
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		var patched []byte
+		found, err = box.GetRaw(id, func(bytes []byte) error {
+			var err error
+			patched, err = box.entity.patch(bytes, changes)
+			return err
+		})
+		if err != nil || !found {
+			return err
+		}
+
+		var obs = box.ObjectBox.observe(OperationPut, box.entity)
+		obs.addBytes(len(patched))
+		err = cCall(func() C.obx_err {
+			return C.obx_box_put5(box.cBox, C.obx_id(id), unsafe.Pointer(&patched[0]), C.size_t(len(patched)), cPutModeUpdate)
+		})
+		obs.done(1, err)
+		return err
+	})
+
+	return found && err == nil, err
+}
+
+// patch creates new FlatBuffers data with the values of the original object, except for the given changes
+func (entity *entity) patch(bytes []byte, changes map[TypeId]interface{}) ([]byte, error) {
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	// collect the resulting values, in the same representation as produced by patchValue()
+	var values = make(map[TypeId]interface{}, len(entity.properties))
+	var lastId TypeId
+	for _, prop := range entity.properties {
+		if prop.id > lastId {
+			lastId = prop.id
+		}
+
+		if value, changed := changes[prop.id]; changed {
+			if value != nil {
+				values[prop.id] = value
+			}
+		} else if value, err := prop.read(table); err != nil {
+			return nil, err
+		} else if value != nil {
+			values[prop.id] = value
+		}
+	}
+
+	return entity.build(values, lastId, len(bytes)), nil
+}
+
+// build creates FlatBuffers data with the given values, in the representation produced by patchValue();
+// lastId is the highest property ID of the entity
+func (entity *entity) build(values map[TypeId]interface{}, lastId TypeId, sizeHint int) []byte {
+	var fbb = flatbuffers.NewBuilder(sizeHint)
+	entity.flatten(fbb, values, lastId)
+	fbb.Finish(fbb.EndObject())
+	return fbb.FinishedBytes()
+}
+
+// flatten writes the given values to the builder, leaving the object open, i.e. like ObjectBinding.Flatten()
+func (entity *entity) flatten(fbb *flatbuffers.Builder, values map[TypeId]interface{}, lastId TypeId) {
+	// non-scalar values must be written before the table is started
+	var offsets = make(map[TypeId]flatbuffers.UOffsetT)
+	for id, value := range values {
+		switch v := value.(type) {
+		case string:
+			offsets[id] = fbutils.CreateStringOffset(fbb, v)
+		case []byte:
+			offsets[id] = fbutils.CreateByteVectorOffset(fbb, v)
+		case []string:
+			offsets[id] = fbutils.CreateStringVectorOffset(fbb, v)
+		}
+	}
+
+	fbb.StartObject(int(lastId))
+	for _, prop := range entity.properties {
+		value, present := values[prop.id]
+		if !present {
+			continue
+		}
+
+		var slot = int(prop.id - 1)
+		if offset, isOffset := offsets[prop.id]; isOffset {
+			fbutils.SetUOffsetTSlot(fbb, slot, offset)
+			continue
+		}
+
+		switch prop.propertyType {
+		case C.OBXPropertyType_Bool:
+			fbutils.SetBoolSlot(fbb, slot, value.(bool))
+		case C.OBXPropertyType_Byte, C.OBXPropertyType_Char:
+			fbutils.SetUint8Slot(fbb, slot, uint8(value.(uint64)))
+		case C.OBXPropertyType_Short:
+			fbutils.SetUint16Slot(fbb, slot, uint16(value.(uint64)))
+		case C.OBXPropertyType_Int:
+			fbutils.SetUint32Slot(fbb, slot, uint32(value.(uint64)))
+		case C.OBXPropertyType_Float:
+			fbutils.SetFloat32Slot(fbb, slot, float32(value.(float64)))
+		case C.OBXPropertyType_Double:
+			fbutils.SetFloat64Slot(fbb, slot, value.(float64))
+		default: // Long, Date, DateNano, Relation
+			fbutils.SetUint64Slot(fbb, slot, value.(uint64))
+		}
+	}
+}
+
+// read returns the property value stored in the given table, or nil if it's not present.
+// Integers are returned as uint64 holding the bit pattern (i.e. regardless of the sign), floats as float64.
+func (prop *property) read(table *flatbuffers.Table) (interface{}, error) {
+	var slot = flatbuffers.VOffsetT(4 + 2*(prop.id-1))
+	if table.Offset(slot) == 0 {
+		return nil, nil
+	}
+
+	switch prop.propertyType {
+	case C.OBXPropertyType_Bool:
+		return fbutils.GetBoolSlot(table, slot), nil
+	case C.OBXPropertyType_Byte, C.OBXPropertyType_Char:
+		return uint64(fbutils.GetUint8Slot(table, slot)), nil
+	case C.OBXPropertyType_Short:
+		return uint64(fbutils.GetUint16Slot(table, slot)), nil
+	case C.OBXPropertyType_Int:
+		return uint64(fbutils.GetUint32Slot(table, slot)), nil
+	case C.OBXPropertyType_Long, C.OBXPropertyType_Date, C.OBXPropertyType_DateNano, C.OBXPropertyType_Relation:
+		return fbutils.GetUint64Slot(table, slot), nil
+	case C.OBXPropertyType_Float:
+		return float64(fbutils.GetFloat32Slot(table, slot)), nil
+	case C.OBXPropertyType_Double:
+		return fbutils.GetFloat64Slot(table, slot), nil
+	case C.OBXPropertyType_String:
+		return fbutils.GetStringSlot(table, slot), nil
+	case C.OBXPropertyType_ByteVector, C.OBXPropertyType_Flex:
+		return fbutils.GetByteVectorSlot(table, slot), nil
+	case C.OBXPropertyType_StringVector:
+		return fbutils.GetStringVectorSlot(table, slot), nil
+	}
+	return nil, fmt.Errorf("property %s has an unsupported type %d", prop.name, prop.propertyType)
+}
+
+// patchValue converts the value given to Set() to the representation used by read()
+func (prop *property) patchValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var rv = reflect.ValueOf(value)
+	switch prop.propertyType {
+	case C.OBXPropertyType_Bool:
+		if rv.Kind() == reflect.Bool {
+			return rv.Bool(), nil
+		}
+	case C.OBXPropertyType_Date, C.OBXPropertyType_DateNano:
+		if t, isTime := value.(time.Time); isTime {
+			if prop.propertyType == C.OBXPropertyType_Date {
+				return uint64(t.UnixNano() / int64(time.Millisecond)), nil
+			}
+			return uint64(t.UnixNano()), nil
+		} else if bits, isInt := integerBits(rv); isInt {
+			return bits, nil
+		}
+	case C.OBXPropertyType_Byte, C.OBXPropertyType_Char, C.OBXPropertyType_Short, C.OBXPropertyType_Int,
+		C.OBXPropertyType_Long, C.OBXPropertyType_Relation:
+		if bits, isInt := integerBits(rv); isInt {
+			return bits, nil
+		}
+	case C.OBXPropertyType_Float, C.OBXPropertyType_Double:
+		if rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64 {
+			return rv.Float(), nil
+		}
+	case C.OBXPropertyType_String:
+		if rv.Kind() == reflect.String {
+			return rv.String(), nil
+		}
+	case C.OBXPropertyType_ByteVector:
+		if v, ok := value.([]byte); ok {
+			return v, nil
+		}
+	case C.OBXPropertyType_StringVector:
+		if v, ok := value.([]string); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("can't set property %s of type %d to a value of type %T", prop.name, prop.propertyType, value)
+}
+
+// integerBits returns the bit pattern of the given integer value, or false if it's not an integer
+func integerBits(rv reflect.Value) (uint64, bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), true
+	}
+	return 0, false
+}
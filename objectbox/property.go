@@ -124,6 +124,24 @@ func (property PropertyString) Contains(text string, caseSensitive bool) Conditi
 	}
 }
 
+// ContainsAnyOf finds entities with the stored property value containing at least one of the given tokens
+func (property PropertyString) ContainsAnyOf(caseSensitive bool, tokens ...string) Condition {
+	return Any(property.containsEach(caseSensitive, tokens)...)
+}
+
+// ContainsAllOf finds entities with the stored property value containing all of the given tokens (in any order)
+func (property PropertyString) ContainsAllOf(caseSensitive bool, tokens ...string) Condition {
+	return All(property.containsEach(caseSensitive, tokens)...)
+}
+
+func (property PropertyString) containsEach(caseSensitive bool, tokens []string) []Condition {
+	var conditions = make([]Condition, len(tokens))
+	for i, token := range tokens {
+		conditions[i] = property.Contains(token, caseSensitive)
+	}
+	return conditions
+}
+
 // HasPrefix finds entities with the stored property value starts with the given text
 func (property PropertyString) HasPrefix(text string, caseSensitive bool) Condition {
 	return &conditionClosure{
@@ -1091,6 +1109,34 @@ func (property PropertyInt16) Between(a, b int16) Condition {
 	}
 }
 
+func (property PropertyInt16) int64Slice(values []int16) []int64 {
+	result := make([]int64, len(values))
+
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+
+	return result
+}
+
+// In finds entities with the stored property value equal to any of the given values
+func (property PropertyInt16) In(values ...int16) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
+// NotIn finds entities with the stored property value not equal to any of the given values
+func (property PropertyInt16) NotIn(values ...int16) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
 // OrderAsc sets ascending order based on this property
 func (property PropertyInt16) OrderAsc() Condition {
 	return property.orderAsc()
@@ -1179,6 +1225,34 @@ func (property PropertyUint16) Between(a, b uint16) Condition {
 	}
 }
 
+func (property PropertyUint16) int64Slice(values []uint16) []int64 {
+	result := make([]int64, len(values))
+
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+
+	return result
+}
+
+// In finds entities with the stored property value equal to any of the given values
+func (property PropertyUint16) In(values ...uint16) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
+// NotIn finds entities with the stored property value not equal to any of the given values
+func (property PropertyUint16) NotIn(values ...uint16) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
 // OrderAsc sets ascending order based on this property
 func (property PropertyUint16) OrderAsc() Condition {
 	return property.orderAsc()
@@ -1267,6 +1341,34 @@ func (property PropertyInt8) Between(a, b int8) Condition {
 	}
 }
 
+func (property PropertyInt8) int64Slice(values []int8) []int64 {
+	result := make([]int64, len(values))
+
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+
+	return result
+}
+
+// In finds entities with the stored property value equal to any of the given values
+func (property PropertyInt8) In(values ...int8) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
+// NotIn finds entities with the stored property value not equal to any of the given values
+func (property PropertyInt8) NotIn(values ...int8) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
 // OrderAsc sets ascending order based on this property
 func (property PropertyInt8) OrderAsc() Condition {
 	return property.orderAsc()
@@ -1355,6 +1457,34 @@ func (property PropertyUint8) Between(a, b uint8) Condition {
 	}
 }
 
+func (property PropertyUint8) int64Slice(values []uint8) []int64 {
+	result := make([]int64, len(values))
+
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+
+	return result
+}
+
+// In finds entities with the stored property value equal to any of the given values
+func (property PropertyUint8) In(values ...uint8) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
+// NotIn finds entities with the stored property value not equal to any of the given values
+func (property PropertyUint8) NotIn(values ...uint8) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
 // OrderAsc sets ascending order based on this property
 func (property PropertyUint8) OrderAsc() Condition {
 	return property.orderAsc()
@@ -1443,6 +1573,34 @@ func (property PropertyByte) Between(a, b byte) Condition {
 	}
 }
 
+func (property PropertyByte) int64Slice(values []byte) []int64 {
+	result := make([]int64, len(values))
+
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+
+	return result
+}
+
+// In finds entities with the stored property value equal to any of the given values
+func (property PropertyByte) In(values ...byte) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
+// NotIn finds entities with the stored property value not equal to any of the given values
+func (property PropertyByte) NotIn(values ...byte) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotIn(property.BaseProperty, property.int64Slice(values))
+		},
+	}
+}
+
 // OrderAsc sets ascending order based on this property
 func (property PropertyByte) OrderAsc() Condition {
 	return property.orderAsc()
@@ -1513,6 +1671,17 @@ func (property PropertyFloat64) Between(a, b float64) Condition {
 	}
 }
 
+// Equals finds entities with the stored property value within the given tolerance of the given value,
+// i.e. between value-tolerance and value+tolerance (including both); floating point numbers can't be compared exactly.
+// The tolerance must not be negative.
+func (property PropertyFloat64) Equals(value, tolerance float64) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleEqual(property.BaseProperty, value, tolerance)
+		},
+	}
+}
+
 // OrderAsc sets ascending order based on this property
 func (property PropertyFloat64) OrderAsc() Condition {
 	return property.orderAsc()
@@ -1583,6 +1752,17 @@ func (property PropertyFloat32) Between(a, b float32) Condition {
 	}
 }
 
+// Equals finds entities with the stored property value within the given tolerance of the given value,
+// i.e. between value-tolerance and value+tolerance (including both); floating point numbers can't be compared exactly.
+// The tolerance must not be negative.
+func (property PropertyFloat32) Equals(value, tolerance float32) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleEqual(property.BaseProperty, float64(value), float64(tolerance))
+		},
+	}
+}
+
 // OrderAsc sets ascending order based on this property
 func (property PropertyFloat32) OrderAsc() Condition {
 	return property.orderAsc()
@@ -22,7 +22,6 @@ package objectbox
 */
 import "C"
 import (
-	"fmt"
 	"runtime"
 	"sync"
 	"unsafe"
@@ -71,30 +70,36 @@ func (pq *PropertyQuery) Close() error {
 func propQueryFinalizer(pq *PropertyQuery) {
 	err := pq.Close()
 	if err != nil {
-		fmt.Printf("Error in PropertyQuery finalizer: %s", err)
+		pq.query.objectBox.logError("error in PropertyQuery finalizer", err, "entity", pq.query.entity.name)
 	}
 }
 
 // Distinct configures the property query to work only on distinct values.
 // Note: not all methods support distinct, those that don't will return an error.
 func (pq *PropertyQuery) Distinct(value bool) error {
-	return cCall(func() C.obx_err {
-		return C.obx_query_prop_distinct(pq.cPropQuery, C.bool(value))
+	return pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_query_prop_distinct(pq.cPropQuery, C.bool(value))
+		})
 	})
 }
 
 // DistinctString configures the property query to work only on distinct values.
 // Note: not all methods support distinct, those that don't will return an error.
 func (pq *PropertyQuery) DistinctString(value, caseSensitive bool) error {
-	return cCall(func() C.obx_err {
-		return C.obx_query_prop_distinct_case(pq.cPropQuery, C.bool(value), C.bool(caseSensitive))
+	return pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_query_prop_distinct_case(pq.cPropQuery, C.bool(value), C.bool(caseSensitive))
+		})
 	})
 }
 
 // Count returns a number of non-NULL values of the given property across all objects matching the query.
 func (pq *PropertyQuery) Count() (uint64, error) {
 	var cResult C.uint64_t
-	if err := cCall(func() C.obx_err { return C.obx_query_prop_count(pq.cPropQuery, &cResult) }); err != nil {
+	if err := pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_prop_count(pq.cPropQuery, &cResult) })
+	}); err != nil {
 		return 0, err
 	}
 	return uint64(cResult), nil
@@ -104,7 +109,9 @@ func (pq *PropertyQuery) Count() (uint64, error) {
 func (pq *PropertyQuery) Average() (float64, error) {
 	var cResult C.double
 	var cCount C.int64_t
-	if err := cCall(func() C.obx_err { return C.obx_query_prop_avg(pq.cPropQuery, &cResult, &cCount) }); err != nil {
+	if err := pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_prop_avg(pq.cPropQuery, &cResult, &cCount) })
+	}); err != nil {
 		return 0, err
 	}
 	return float64(cResult), nil
@@ -113,7 +120,9 @@ func (pq *PropertyQuery) Average() (float64, error) {
 // MinFloat64 finds the minimum value of the given floating-point property across all objects matching the query.
 func (pq *PropertyQuery) MinFloat64() (float64, error) {
 	var cResult C.double
-	if err := cCall(func() C.obx_err { return C.obx_query_prop_min(pq.cPropQuery, &cResult, nil) }); err != nil {
+	if err := pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_prop_min(pq.cPropQuery, &cResult, nil) })
+	}); err != nil {
 		return 0, err
 	}
 	return float64(cResult), nil
@@ -122,7 +131,9 @@ func (pq *PropertyQuery) MinFloat64() (float64, error) {
 // MaxFloat64 finds the maximum value of the given floating-point property across all objects matching the query.
 func (pq *PropertyQuery) MaxFloat64() (float64, error) {
 	var cResult C.double
-	if err := cCall(func() C.obx_err { return C.obx_query_prop_max(pq.cPropQuery, &cResult, nil) }); err != nil {
+	if err := pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_prop_max(pq.cPropQuery, &cResult, nil) })
+	}); err != nil {
 		return 0, err
 	}
 	return float64(cResult), nil
@@ -131,7 +142,9 @@ func (pq *PropertyQuery) MaxFloat64() (float64, error) {
 // SumFloat64 calculates the sum of the given floating-point property across all objects matching the query.
 func (pq *PropertyQuery) SumFloat64() (float64, error) {
 	var cResult C.double
-	if err := cCall(func() C.obx_err { return C.obx_query_prop_sum(pq.cPropQuery, &cResult, nil) }); err != nil {
+	if err := pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_prop_sum(pq.cPropQuery, &cResult, nil) })
+	}); err != nil {
 		return 0, err
 	}
 	return float64(cResult), nil
@@ -140,7 +153,9 @@ func (pq *PropertyQuery) SumFloat64() (float64, error) {
 // Min finds the minimum value of the given property across all objects matching the query.
 func (pq *PropertyQuery) Min() (int64, error) {
 	var cResult C.int64_t
-	if err := cCall(func() C.obx_err { return C.obx_query_prop_min_int(pq.cPropQuery, &cResult, nil) }); err != nil {
+	if err := pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_prop_min_int(pq.cPropQuery, &cResult, nil) })
+	}); err != nil {
 		return 0, err
 	}
 	return int64(cResult), nil
@@ -149,7 +164,9 @@ func (pq *PropertyQuery) Min() (int64, error) {
 // Max finds the maximum value of the given property across all objects matching the query.
 func (pq *PropertyQuery) Max() (int64, error) {
 	var cResult C.int64_t
-	if err := cCall(func() C.obx_err { return C.obx_query_prop_max_int(pq.cPropQuery, &cResult, nil) }); err != nil {
+	if err := pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_prop_max_int(pq.cPropQuery, &cResult, nil) })
+	}); err != nil {
 		return 0, err
 	}
 	return int64(cResult), nil
@@ -158,7 +175,9 @@ func (pq *PropertyQuery) Max() (int64, error) {
 // Sum calculates the sum of the given property across all objects matching the query.
 func (pq *PropertyQuery) Sum() (int64, error) {
 	var cResult C.int64_t
-	if err := cCall(func() C.obx_err { return C.obx_query_prop_sum_int(pq.cPropQuery, &cResult, nil) }); err != nil {
+	if err := pq.query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_prop_sum_int(pq.cPropQuery, &cResult, nil) })
+	}); err != nil {
 		return 0, err
 	}
 	return int64(cResult), nil
@@ -167,169 +186,225 @@ func (pq *PropertyQuery) Sum() (int64, error) {
 // FindInts returns an int slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindInts(valueIfNil *int) ([]int, error) {
-	return cGetInts(func() *C.OBX_int64_array {
-		if valueIfNil == nil {
-			return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
-		} else {
-			var cValueIfNil = C.int64_t(*valueIfNil)
-			return C.obx_query_prop_find_int64s(pq.cPropQuery, &cValueIfNil)
-		}
+func (pq *PropertyQuery) FindInts(valueIfNil *int) (result []int, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetInts(func() *C.OBX_int64_array {
+			if valueIfNil == nil {
+				return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
+			} else {
+				var cValueIfNil = C.int64_t(*valueIfNil)
+				return C.obx_query_prop_find_int64s(pq.cPropQuery, &cValueIfNil)
+			}
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindUints returns an uint slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindUints(valueIfNil *uint) ([]uint, error) {
-	return cGetUints(func() *C.OBX_int64_array {
-		if valueIfNil == nil {
-			return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
-		} else {
-			var cValueIfNil = C.int64_t(*valueIfNil)
-			return C.obx_query_prop_find_int64s(pq.cPropQuery, &cValueIfNil)
-		}
+func (pq *PropertyQuery) FindUints(valueIfNil *uint) (result []uint, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetUints(func() *C.OBX_int64_array {
+			if valueIfNil == nil {
+				return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
+			} else {
+				var cValueIfNil = C.int64_t(*valueIfNil)
+				return C.obx_query_prop_find_int64s(pq.cPropQuery, &cValueIfNil)
+			}
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindInt64s returns an int64 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindInt64s(valueIfNil *int64) ([]int64, error) {
-	return cGetInt64s(func() *C.OBX_int64_array {
-		return C.obx_query_prop_find_int64s(pq.cPropQuery, (*C.int64_t)(valueIfNil))
+func (pq *PropertyQuery) FindInt64s(valueIfNil *int64) (result []int64, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetInt64s(func() *C.OBX_int64_array {
+			return C.obx_query_prop_find_int64s(pq.cPropQuery, (*C.int64_t)(valueIfNil))
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindUint64s returns an uint64 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindUint64s(valueIfNil *uint64) ([]uint64, error) {
-	return cGetUint64s(func() *C.OBX_int64_array {
-		if valueIfNil == nil {
-			return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
-		} else {
-			var cValueIfNil = C.int64_t(*valueIfNil)
-			return C.obx_query_prop_find_int64s(pq.cPropQuery, &cValueIfNil)
-		}
+func (pq *PropertyQuery) FindUint64s(valueIfNil *uint64) (result []uint64, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetUint64s(func() *C.OBX_int64_array {
+			if valueIfNil == nil {
+				return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
+			} else {
+				var cValueIfNil = C.int64_t(*valueIfNil)
+				return C.obx_query_prop_find_int64s(pq.cPropQuery, &cValueIfNil)
+			}
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindInt32s returns an int32 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindInt32s(valueIfNil *int32) ([]int32, error) {
-	return cGetInt32s(func() *C.OBX_int32_array {
-		return C.obx_query_prop_find_int32s(pq.cPropQuery, (*C.int32_t)(valueIfNil))
+func (pq *PropertyQuery) FindInt32s(valueIfNil *int32) (result []int32, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetInt32s(func() *C.OBX_int32_array {
+			return C.obx_query_prop_find_int32s(pq.cPropQuery, (*C.int32_t)(valueIfNil))
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindUint32s returns an uint32 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindUint32s(valueIfNil *uint32) ([]uint32, error) {
-	return cGetUint32s(func() *C.OBX_int32_array {
-		if valueIfNil == nil {
-			return C.obx_query_prop_find_int32s(pq.cPropQuery, nil)
-		} else {
-			var cValueIfNil = C.int32_t(*valueIfNil)
-			return C.obx_query_prop_find_int32s(pq.cPropQuery, &cValueIfNil)
-		}
+func (pq *PropertyQuery) FindUint32s(valueIfNil *uint32) (result []uint32, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetUint32s(func() *C.OBX_int32_array {
+			if valueIfNil == nil {
+				return C.obx_query_prop_find_int32s(pq.cPropQuery, nil)
+			} else {
+				var cValueIfNil = C.int32_t(*valueIfNil)
+				return C.obx_query_prop_find_int32s(pq.cPropQuery, &cValueIfNil)
+			}
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindInt16s returns an int16 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindInt16s(valueIfNil *int16) ([]int16, error) {
-	return cGetInt16s(func() *C.OBX_int16_array {
-		return C.obx_query_prop_find_int16s(pq.cPropQuery, (*C.int16_t)(valueIfNil))
+func (pq *PropertyQuery) FindInt16s(valueIfNil *int16) (result []int16, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetInt16s(func() *C.OBX_int16_array {
+			return C.obx_query_prop_find_int16s(pq.cPropQuery, (*C.int16_t)(valueIfNil))
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindUint16s returns an uint16 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindUint16s(valueIfNil *uint16) ([]uint16, error) {
-	return cGetUint16s(func() *C.OBX_int16_array {
-		if valueIfNil == nil {
-			return C.obx_query_prop_find_int16s(pq.cPropQuery, nil)
-		} else {
-			var cValueIfNil = C.int16_t(*valueIfNil)
-			return C.obx_query_prop_find_int16s(pq.cPropQuery, &cValueIfNil)
-		}
+func (pq *PropertyQuery) FindUint16s(valueIfNil *uint16) (result []uint16, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetUint16s(func() *C.OBX_int16_array {
+			if valueIfNil == nil {
+				return C.obx_query_prop_find_int16s(pq.cPropQuery, nil)
+			} else {
+				var cValueIfNil = C.int16_t(*valueIfNil)
+				return C.obx_query_prop_find_int16s(pq.cPropQuery, &cValueIfNil)
+			}
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindInt8s returns an int8 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindInt8s(valueIfNil *int8) ([]int8, error) {
-	return cGetInt8s(func() *C.OBX_int8_array {
-		return C.obx_query_prop_find_int8s(pq.cPropQuery, (*C.int8_t)(valueIfNil))
+func (pq *PropertyQuery) FindInt8s(valueIfNil *int8) (result []int8, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetInt8s(func() *C.OBX_int8_array {
+			return C.obx_query_prop_find_int8s(pq.cPropQuery, (*C.int8_t)(valueIfNil))
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindUint8s returns an int8 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindUint8s(valueIfNil *uint8) ([]uint8, error) {
-	return cGetUint8s(func() *C.OBX_int8_array {
-		if valueIfNil == nil {
-			return C.obx_query_prop_find_int8s(pq.cPropQuery, nil)
-		} else {
-			var cValueIfNil = C.int8_t(*valueIfNil)
-			return C.obx_query_prop_find_int8s(pq.cPropQuery, &cValueIfNil)
-		}
+func (pq *PropertyQuery) FindUint8s(valueIfNil *uint8) (result []uint8, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetUint8s(func() *C.OBX_int8_array {
+			if valueIfNil == nil {
+				return C.obx_query_prop_find_int8s(pq.cPropQuery, nil)
+			} else {
+				var cValueIfNil = C.int8_t(*valueIfNil)
+				return C.obx_query_prop_find_int8s(pq.cPropQuery, &cValueIfNil)
+			}
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindFloat64s returns a float64 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindFloat64s(valueIfNil *float64) ([]float64, error) {
-	return cGetFloat64s(func() *C.OBX_double_array {
-		return C.obx_query_prop_find_doubles(pq.cPropQuery, (*C.double)(valueIfNil))
+func (pq *PropertyQuery) FindFloat64s(valueIfNil *float64) (result []float64, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetFloat64s(func() *C.OBX_double_array {
+			return C.obx_query_prop_find_doubles(pq.cPropQuery, (*C.double)(valueIfNil))
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindFloat32s returns a float32 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindFloat32s(valueIfNil *float32) ([]float32, error) {
-	return cGetFloat32s(func() *C.OBX_float_array {
-		return C.obx_query_prop_find_floats(pq.cPropQuery, (*C.float)(valueIfNil))
+func (pq *PropertyQuery) FindFloat32s(valueIfNil *float32) (result []float32, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetFloat32s(func() *C.OBX_float_array {
+			return C.obx_query_prop_find_floats(pq.cPropQuery, (*C.float)(valueIfNil))
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindBools returns an int8 slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindBools(valueIfNil *bool) ([]bool, error) {
-	return cGetBools(func() *C.OBX_int8_array {
-		if valueIfNil == nil {
-			return C.obx_query_prop_find_int8s(pq.cPropQuery, nil)
-		} else {
-			var cValueIfNil = C.int8_t(0)
-			if *valueIfNil {
-				cValueIfNil = 1
+func (pq *PropertyQuery) FindBools(valueIfNil *bool) (result []bool, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetBools(func() *C.OBX_int8_array {
+			if valueIfNil == nil {
+				return C.obx_query_prop_find_int8s(pq.cPropQuery, nil)
+			} else {
+				var cValueIfNil = C.int8_t(0)
+				if *valueIfNil {
+					cValueIfNil = 1
+				}
+				return C.obx_query_prop_find_int8s(pq.cPropQuery, &cValueIfNil)
 			}
-			return C.obx_query_prop_find_int8s(pq.cPropQuery, &cValueIfNil)
-		}
+		})
+		return err
 	})
+	return result, err
 }
 
 // FindStrings returns a string slice composed of values of the given property across all objects matching the query.
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
-func (pq *PropertyQuery) FindStrings(valueIfNil *string) ([]string, error) {
-	return cGetStrings(func() *C.OBX_string_array {
-		if valueIfNil == nil {
-			return C.obx_query_prop_find_strings(pq.cPropQuery, nil)
-		} else {
-			var cValueIfNil = C.CString(*valueIfNil)
-			defer C.free(unsafe.Pointer(cValueIfNil))
-			return C.obx_query_prop_find_strings(pq.cPropQuery, cValueIfNil)
-		}
+func (pq *PropertyQuery) FindStrings(valueIfNil *string) (result []string, err error) {
+	err = pq.query.objectBox.guard(func() error {
+		result, err = cGetStrings(func() *C.OBX_string_array {
+			if valueIfNil == nil {
+				return C.obx_query_prop_find_strings(pq.cPropQuery, nil)
+			} else {
+				var cValueIfNil = C.CString(*valueIfNil)
+				defer C.free(unsafe.Pointer(cValueIfNil))
+				return C.obx_query_prop_find_strings(pq.cPropQuery, cValueIfNil)
+			}
+		})
+		return err
 	})
+	return result, err
 }
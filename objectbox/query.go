@@ -22,10 +22,12 @@ package objectbox
 */
 import "C"
 import (
+	"context"
 	"errors"
 	"fmt"
 	"runtime"
 	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -40,11 +42,33 @@ type Query struct {
 	box             *Box
 	cQuery          *C.OBX_query
 	closeMutex      sync.Mutex
+	offset          uint64
 	offsetErr       error
+	limit           uint64
 	limitErr        error
+	maxResults      uint64
+	timeout         time.Duration
 	linkedEntityIds []TypeId
+
+	// conditions and order the query was built with, used to derive the queries reading a single page
+	conditions []Condition
+	orderIds   []TypeId
+	orderFlags map[TypeId]C.OBXOrderFlags
+
+	// parameters changed after the query was built, to be applied to the derived queries as well
+	params map[paramKey]func(query *Query) error
+}
+
+// paramKey identifies the condition whose parameters were changed, see Query.rememberParams()
+type paramKey struct {
+	alias      string
+	entityId   TypeId
+	propertyId TypeId
 }
 
+// ErrMaxResultsExceeded is returned by queries matching more objects than allowed by Query.MaxResults()
+var ErrMaxResultsExceeded = errors.New("query matches more objects than the configured maximum")
+
 // Close frees (native) resources held by this Query.
 // Note that this is optional and not required because the GC invokes a finalizer automatically.
 func (query *Query) Close() error {
@@ -65,7 +89,7 @@ func (query *Query) Close() error {
 func queryFinalizer(query *Query) {
 	err := query.Close()
 	if err != nil {
-		fmt.Printf("Error in Query finalizer: %s", err)
+		query.objectBox.logError("error in Query finalizer", err, "entity", query.entity.name)
 	}
 }
 
@@ -88,6 +112,53 @@ func (query *Query) check() error {
 	return nil
 }
 
+// checkForRead is like check() but also waits for pending async operations if configured for the box
+func (query *Query) checkForRead() error {
+	if err := query.check(); err != nil {
+		return err
+	}
+	return query.box.awaitPendingAsync()
+}
+
+// Clone creates an independent copy of the query, including the current parameters, offset and limit.
+// A Query must not be used by multiple goroutines at the same time; use Clone to get a separate instance for each
+// goroutine instead, e.g. to reuse a prepared query "template" in concurrent HTTP handlers.
+func (query *Query) Clone() (*Query, error) {
+	defer runtime.KeepAlive(query)
+
+	if err := query.check(); err != nil {
+		return nil, err
+	}
+
+	var clone = &Query{
+		entity:          query.entity,
+		objectBox:       query.objectBox,
+		box:             query.box,
+		offset:          query.offset,
+		limit:           query.limit,
+		maxResults:      query.maxResults,
+		timeout:         query.timeout,
+		linkedEntityIds: query.linkedEntityIds,
+		conditions:      query.conditions,
+		orderIds:        query.orderIds,
+		orderFlags:      query.orderFlags,
+	}
+
+	for key, setParams := range query.params {
+		clone.rememberParams(key, setParams)
+	}
+
+	if err := cCallBool(func() bool {
+		clone.cQuery = C.obx_query_clone(query.cQuery)
+		return clone.cQuery != nil
+	}); err != nil {
+		return nil, err
+	}
+
+	clone.installFinalizer()
+	return clone, nil
+}
+
 // Property provides a way to access a value of a single property or run aggregate functions.
 // Note: this method panics in case a property query could not be created, e.g. property doesn't belong to the queried
 // entity. Consider using PropertyOrError if you need an explicit error check, e.g. when using dynamic arguments.
@@ -112,73 +183,338 @@ func (query *Query) PropertyOrError(prop Property) (*PropertyQuery, error) {
 func (query *Query) Find() (objects interface{}, err error) {
 	defer runtime.KeepAlive(query)
 
-	if err := query.check(); err != nil {
+	var obs = query.objectBox.observe(OperationQuery, query.entity)
+	defer func() { obs.done(sliceLen(objects), err) }()
+
+	if err := query.checkForRead(); err != nil {
 		return nil, err
 	}
 
 	const existingOnly = true
-	if supportsResultArray {
+	if supportsResultArray && query.maxResults == 0 && query.timeout == 0 {
 		var cFn = func() *C.OBX_bytes_array {
 			return C.obx_query_find(query.cQuery)
 		}
 		return query.box.readManyObjects(existingOnly, cFn)
 	}
 
+	ctx, cancel := query.withTimeout(context.Background())
+	defer cancel()
+
 	var cFn = func(visitorArg unsafe.Pointer) C.obx_err {
 		return C.obx_query_visit(query.cQuery, dataVisitor, visitorArg)
 	}
-	return query.box.readUsingVisitor(existingOnly, cFn)
+	return query.box.readUsingVisitor(ctx, existingOnly, query.maxResults, cFn)
+}
+
+// FindRaw calls the given function with the FlatBuffers data of each object matching the query, without creating
+// the Go objects. Return false from the function to stop. The bytes are only valid during the callback;
+// use the fbutils package or flatbuffers.Table to read the fields you're interested in.
+func (query *Query) FindRaw(fn func(bytes []byte) bool) error {
+	defer runtime.KeepAlive(query)
+
+	if err := query.checkForRead(); err != nil {
+		return err
+	}
+
+	ctx, cancel := query.withTimeout(context.Background())
+	defer cancel()
+
+	var count uint64
+	var err error
+	visitor, err := dataVisitorRegister(func(bytes []byte) bool {
+		if err = ctx.Err(); err != nil {
+			return false
+		} else if count++; query.maxResults > 0 && count > query.maxResults {
+			err = ErrMaxResultsExceeded
+			return false
+		}
+		return fn(bytes)
+	})
+	if err != nil {
+		return err
+	}
+	defer dataVisitorUnregister(visitor)
+
+	// the read transaction keeps the data untouched (by a concurrent write) during the visit
+	// use another `error` variable as `err` may be set by the visitor callback above
+	var err2 = query.objectBox.RunInReadTx(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_query_visit(query.cQuery, dataVisitor, unsafe.Pointer(&visitor))
+		})
+	})
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
+
+// FindFirst returns the first object matching the query or nil if there's no match.
+// Note: the query offset is ignored.
+func (query *Query) FindFirst() (object interface{}, err error) {
+	return query.findSingle(func(data *unsafe.Pointer, size *C.size_t) C.obx_err {
+		return C.obx_query_find_first(query.cQuery, data, size)
+	})
+}
+
+// FindUnique returns the only object matching the query or nil if there's no match.
+// Returns an error if more than one object matches the query. Note: the query offset and limit are ignored.
+func (query *Query) FindUnique() (object interface{}, err error) {
+	return query.findSingle(func(data *unsafe.Pointer, size *C.size_t) C.obx_err {
+		return C.obx_query_find_unique(query.cQuery, data, size)
+	})
+}
+
+func (query *Query) findSingle(cFn func(data *unsafe.Pointer, size *C.size_t) C.obx_err) (object interface{}, err error) {
+	defer runtime.KeepAlive(query)
+
+	if err := query.checkForRead(); err != nil {
+		return nil, err
+	}
+
+	// we need a read-transaction to keep the data in dataPtr untouched (by concurrent write) until we can read it
+	// as well as making sure the relations read in binding.Load represent a consistent state
+	err = query.objectBox.RunInReadTx(func() error {
+		var dataPtr unsafe.Pointer
+		var dataSize C.size_t
+
+		var rc = cFn(&dataPtr, &dataSize)
+		if rc == 0 {
+			var bytes []byte
+			cVoidPtrToByteSlice(dataPtr, int(dataSize), &bytes)
+			object, err = query.entity.load(query.objectBox, bytes)
+			return err
+		} else if rc == C.OBX_NOT_FOUND {
+			object = nil
+			return nil
+		} else {
+			object = nil
+			// NOTE: no need for manual runtime.LockOSThread() because we're inside a read transaction
+			return createError()
+		}
+	})
+
+	return object, err
+}
+
+// FindCtx is like Find but stops reading and returns ctx.Err() as soon as the given context is done.
+func (query *Query) FindCtx(ctx context.Context) (objects interface{}, err error) {
+	defer runtime.KeepAlive(query)
+
+	if err := query.checkForRead(); err != nil {
+		return nil, err
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := query.withTimeout(ctx)
+	defer cancel()
+
+	// always use the visitor so that the context can be checked between the objects
+	const existingOnly = true
+	var cFn = func(visitorArg unsafe.Pointer) C.obx_err {
+		return C.obx_query_visit(query.cQuery, dataVisitor, visitorArg)
+	}
+	return query.box.readUsingVisitor(ctx, existingOnly, query.maxResults, cFn)
+}
+
+// MaxResults makes Find, FindCtx, FindRaw and FindIds fail with ErrMaxResultsExceeded if more than the given number
+// of objects match the query (0 = no maximum), e.g. to protect a memory-constrained device from a too broad filter.
+// As opposed to Limit(), the result isn't silently truncated. Find stops reading as soon as the maximum is exceeded.
+func (query *Query) MaxResults(max uint64) *Query {
+	query.maxResults = max
+	return query
+}
+
+// Timeout makes Find, FindCtx and FindRaw fail with context.DeadlineExceeded if reading the results takes longer
+// than the given duration (0 = no timeout). The time is checked between the visited objects.
+func (query *Query) Timeout(timeout time.Duration) *Query {
+	query.timeout = timeout
+	return query
+}
+
+// withTimeout returns the context to use for reading the results, considering the timeout configured on the query
+func (query *Query) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if query.timeout > 0 {
+		return context.WithTimeout(ctx, query.timeout)
+	}
+	return context.WithCancel(ctx)
 }
 
 // Offset defines the index of the first object to process (how many objects to skip)
 func (query *Query) Offset(offset uint64) *Query {
 	query.offsetErr = cCall(func() C.obx_err { return C.obx_query_offset(query.cQuery, C.size_t(offset)) })
+	if query.offsetErr == nil {
+		query.offset = offset
+	}
 	return query
 }
 
 // Limit sets the number of elements to process by the query
 func (query *Query) Limit(limit uint64) *Query {
 	query.limitErr = cCall(func() C.obx_err { return C.obx_query_limit(query.cQuery, C.size_t(limit)) })
+	if query.limitErr == nil {
+		query.limit = limit
+	}
 	return query
 }
 
 // FindIds returns IDs of all objects matching the query
-func (query *Query) FindIds() ([]uint64, error) {
+func (query *Query) FindIds() (ids []uint64, err error) {
+	var obs = query.objectBox.observe(OperationQuery, query.entity)
+	if err = query.box.awaitPendingAsync(); err == nil {
+		ids, err = query.findIds()
+	}
+	obs.done(len(ids), err)
+	return ids, err
+}
+
+func (query *Query) findIds() ([]uint64, error) {
 	defer runtime.KeepAlive(query)
 
 	if err := query.check(); err != nil {
 		return nil, err
 	}
 
-	return cGetIds(func() *C.OBX_id_array {
-		return C.obx_query_find_ids(query.cQuery)
+	var ids []uint64
+	var findIds = func() (err error) {
+		ids, err = cGetIds(func() *C.OBX_id_array {
+			return C.obx_query_find_ids(query.cQuery)
+		})
+		return err
+	}
+
+	// with MaxResults, fetch at most one ID over the maximum instead of all matching IDs; offset/limit are native
+	var err = query.objectBox.guard(func() error {
+		if query.maxResults > 0 {
+			return query.withLimit(query.maxResults+1, findIds)
+		}
+		return findIds()
 	})
+
+	if err == nil && query.maxResults > 0 && uint64(len(ids)) > query.maxResults {
+		return nil, ErrMaxResultsExceeded
+	}
+	return ids, err
 }
 
 // Count returns the number of objects matching the query.
 // Currently can't be used in combination with Offset().
-func (query *Query) Count() (uint64, error) {
-	if err := query.check(); err != nil {
+func (query *Query) Count() (count uint64, err error) {
+	var obs = query.objectBox.observe(OperationQuery, query.entity)
+	defer func() { obs.done(int(count), err) }()
+
+	if err := query.checkForRead(); err != nil {
 		return 0, err
 	}
 
 	var cResult C.uint64_t
-	if err := cCall(func() C.obx_err { return C.obx_query_count(query.cQuery, &cResult) }); err != nil {
+	if err := query.objectBox.guard(func() error {
+		return cCall(func() C.obx_err { return C.obx_query_count(query.cQuery, &cResult) })
+	}); err != nil {
 		return 0, err
 	}
 	runtime.KeepAlive(query)
 	return uint64(cResult), nil
 }
 
+// CountMax returns the number of objects matching the query, but at most max.
+// Counting stops as soon as the maximum is reached, e.g. to show "99+" without counting all matching objects.
+// A limit set by Limit() still applies. Currently can't be used in combination with Offset().
+func (query *Query) CountMax(max uint64) (count uint64, err error) {
+	if max == 0 {
+		return 0, errors.New("max must be greater than zero")
+	}
+
+	var obs = query.objectBox.observe(OperationQuery, query.entity)
+	defer func() { obs.done(int(count), err) }()
+
+	if err := query.checkForRead(); err != nil {
+		return 0, err
+	}
+
+	var cResult C.uint64_t
+	err = query.objectBox.guard(func() error {
+		return query.withLimit(max, func() error {
+			return cCall(func() C.obx_err { return C.obx_query_count(query.cQuery, &cResult) })
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	runtime.KeepAlive(query)
+	return uint64(cResult), nil
+}
+
+// Exists returns true if at least one object matches the query; it stops at the first match.
+func (query *Query) Exists() (bool, error) {
+	count, err := query.CountMax(1)
+	return count > 0, err
+}
+
+// withLimit runs fn with the native query limit lowered to the given one (if the limit set by Limit() is higher),
+// and restores the limit afterwards.
+func (query *Query) withLimit(limit uint64, fn func() error) error {
+	if query.limit != 0 && query.limit <= limit {
+		return fn()
+	}
+
+	if err := cCall(func() C.obx_err { return C.obx_query_limit(query.cQuery, C.size_t(limit)) }); err != nil {
+		return err
+	}
+
+	var err = fn()
+
+	// restore the limit set by the user
+	if errLimit := cCall(func() C.obx_err { return C.obx_query_limit(query.cQuery, C.size_t(query.limit)) }); err == nil {
+		err = errLimit
+	}
+	return err
+}
+
 // Remove permanently deletes all objects matching the query from the database.
-// Currently can't be used in combination with Offset() or Limit().
+// If Offset() or Limit() is set, only the objects in that range are removed, e.g. to delete the oldest N objects.
 func (query *Query) Remove() (count uint64, err error) {
-	if err := query.check(); err != nil {
+	var obs = query.objectBox.observe(OperationRemove, query.entity)
+	defer func() { obs.done(int(count), err) }()
+
+	if err := query.checkForRead(); err != nil {
 		return 0, err
 	}
 
+	if query.box.softDelete != nil {
+		// find and mark the objects in the same transaction, so that none are added or changed in between
+		err = query.objectBox.RunInWriteTx(func() error {
+			ids, err := query.findIds()
+			if err == nil && len(ids) > 0 {
+				count, err = query.box.softRemoveIds(ids)
+			}
+			return err
+		})
+		return count, err
+	}
+
+	// the native remove doesn't support offset/limit so remove the IDs from the same range as Find() would return
+	if query.offset != 0 || query.limit != 0 {
+		err = query.objectBox.RunInWriteTx(func() error {
+			ids, err := query.findIds()
+			if err == nil && len(ids) > 0 {
+				count, err = query.box.removeIds(ids)
+			}
+			return err
+		})
+		return count, err
+	}
+
 	var cResult C.uint64_t
-	if err := cCall(func() C.obx_err { return C.obx_query_remove(query.cQuery, &cResult) }); err != nil {
+	if err := query.objectBox.guard(func() error {
+		if err := query.objectBox.writeQueue.acquire(); err != nil {
+			return err
+		}
+		defer query.objectBox.writeQueue.release()
+
+		return cCall(func() C.obx_err { return C.obx_query_remove(query.cQuery, &cResult) })
+	}); err != nil {
 		return 0, err
 	}
 
@@ -186,17 +522,51 @@ func (query *Query) Remove() (count uint64, err error) {
 	return uint64(cResult), nil
 }
 
+// RemoveDryRun returns IDs of the objects that Remove() would delete, without removing anything.
+// The number of objects to be removed is the length of the returned slice.
+func (query *Query) RemoveDryRun() ([]uint64, error) {
+	return query.FindIds()
+}
+
+// Describe returns a string describing the query, e.g. the entity, the number of conditions and their properties.
+// Useful to check how conditions were combined; use DescribeParams() to see the current parameter values.
+func (query *Query) Describe() (result string, err error) {
+	if err := query.check(); err != nil {
+		return "", err
+	}
+
+	err = query.objectBox.guard(func() error {
+		// no need to free, it's handled by the cQuery internally
+		result = C.GoString(C.obx_query_describe(query.cQuery))
+		return nil
+	})
+
+	runtime.KeepAlive(query)
+	return result, err
+}
+
+// DescribeTree returns the condition tree of the query, i.e. how the conditions are combined using AND/OR, including
+// the properties and the current parameter values. It's the same as DescribeParams(), named for discoverability.
+// Note: an execution plan (whether a condition uses an index, the estimated number of candidate objects) isn't
+// available as the ObjectBox C API doesn't expose it.
+func (query *Query) DescribeTree() (string, error) {
+	return query.DescribeParams()
+}
+
 // DescribeParams returns a string representation of the query conditions
-func (query *Query) DescribeParams() (string, error) {
+func (query *Query) DescribeParams() (result string, err error) {
 	if err := query.check(); err != nil {
 		return "", err
 	}
 
-	// no need to free, it's handled by the cQuery internally
-	cResult := C.obx_query_describe_params(query.cQuery)
+	err = query.objectBox.guard(func() error {
+		// no need to free, it's handled by the cQuery internally
+		result = C.GoString(C.obx_query_describe_params(query.cQuery))
+		return nil
+	})
 
 	runtime.KeepAlive(query)
-	return C.GoString(cResult), nil
+	return result, err
 }
 
 func (query *Query) checkIdentifier(identifier propertyOrAlias) error {
@@ -239,9 +609,39 @@ type propertyOrAlias interface {
 	alias() *string
 }
 
+// rememberParams stores a function changing the parameters of a condition, to be applied to derived queries
+func (query *Query) rememberParams(key paramKey, setParams func(query *Query) error) {
+	if query.params == nil {
+		query.params = make(map[paramKey]func(query *Query) error)
+	}
+	query.params[key] = setParams
+}
+
+// applyParams changes the parameters of the given query (derived from this one) to the ones set on this query
+func (query *Query) applyParams(derived *Query) error {
+	for _, setParams := range query.params {
+		if err := setParams(derived); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newParamKey(identifier propertyOrAlias) paramKey {
+	if alias := identifier.alias(); alias != nil {
+		return paramKey{alias: *alias}
+	}
+	return paramKey{entityId: identifier.entityId(), propertyId: identifier.propertyId()}
+}
+
 // SetStringParams changes query parameter values on the given property
-func (query *Query) SetStringParams(identifier propertyOrAlias, values ...string) error {
+func (query *Query) SetStringParams(identifier propertyOrAlias, values ...string) (err error) {
 	defer runtime.KeepAlive(query)
+	defer func() {
+		if err == nil {
+			query.rememberParams(newParamKey(identifier), func(q *Query) error { return q.SetStringParams(identifier, values...) })
+		}
+	}()
 
 	if err := query.checkIdentifier(identifier); err != nil {
 		return err
@@ -273,8 +673,13 @@ func (query *Query) SetStringParams(identifier propertyOrAlias, values ...string
 }
 
 // SetStringParamsIn changes query parameter values on the given property
-func (query *Query) SetStringParamsIn(identifier propertyOrAlias, values ...string) error {
+func (query *Query) SetStringParamsIn(identifier propertyOrAlias, values ...string) (err error) {
 	defer runtime.KeepAlive(query)
+	defer func() {
+		if err == nil {
+			query.rememberParams(newParamKey(identifier), func(q *Query) error { return q.SetStringParamsIn(identifier, values...) })
+		}
+	}()
 
 	if err := query.checkIdentifier(identifier); err != nil {
 		return err
@@ -302,8 +707,13 @@ func (query *Query) SetStringParamsIn(identifier propertyOrAlias, values ...stri
 }
 
 // SetInt64Params changes query parameter values on the given property
-func (query *Query) SetInt64Params(identifier propertyOrAlias, values ...int64) error {
+func (query *Query) SetInt64Params(identifier propertyOrAlias, values ...int64) (err error) {
 	defer runtime.KeepAlive(query)
+	defer func() {
+		if err == nil {
+			query.rememberParams(newParamKey(identifier), func(q *Query) error { return q.SetInt64Params(identifier, values...) })
+		}
+	}()
 
 	if err := query.checkIdentifier(identifier); err != nil {
 		return err
@@ -339,9 +749,22 @@ func (query *Query) SetInt64Params(identifier propertyOrAlias, values ...int64)
 	return fmt.Errorf("too many values given")
 }
 
+// SetBoolParams changes the query parameter value on the given bool property, e.g. created with Equals(false)
+func (query *Query) SetBoolParams(identifier propertyOrAlias, value bool) error {
+	if value {
+		return query.SetInt64Params(identifier, 1)
+	}
+	return query.SetInt64Params(identifier, 0)
+}
+
 // SetInt64ParamsIn changes query parameter values on the given property
-func (query *Query) SetInt64ParamsIn(identifier propertyOrAlias, values ...int64) error {
+func (query *Query) SetInt64ParamsIn(identifier propertyOrAlias, values ...int64) (err error) {
 	defer runtime.KeepAlive(query)
+	defer func() {
+		if err == nil {
+			query.rememberParams(newParamKey(identifier), func(q *Query) error { return q.SetInt64ParamsIn(identifier, values...) })
+		}
+	}()
 
 	if err := query.checkIdentifier(identifier); err != nil {
 		return err
@@ -366,8 +789,13 @@ func (query *Query) SetInt64ParamsIn(identifier propertyOrAlias, values ...int64
 }
 
 // SetInt32ParamsIn changes query parameter values on the given property
-func (query *Query) SetInt32ParamsIn(identifier propertyOrAlias, values ...int32) error {
+func (query *Query) SetInt32ParamsIn(identifier propertyOrAlias, values ...int32) (err error) {
 	defer runtime.KeepAlive(query)
+	defer func() {
+		if err == nil {
+			query.rememberParams(newParamKey(identifier), func(q *Query) error { return q.SetInt32ParamsIn(identifier, values...) })
+		}
+	}()
 
 	if err := query.checkIdentifier(identifier); err != nil {
 		return err
@@ -392,8 +820,13 @@ func (query *Query) SetInt32ParamsIn(identifier propertyOrAlias, values ...int32
 }
 
 // SetFloat64Params changes query parameter values on the given property
-func (query *Query) SetFloat64Params(identifier propertyOrAlias, values ...float64) error {
+func (query *Query) SetFloat64Params(identifier propertyOrAlias, values ...float64) (err error) {
 	defer runtime.KeepAlive(query)
+	defer func() {
+		if err == nil {
+			query.rememberParams(newParamKey(identifier), func(q *Query) error { return q.SetFloat64Params(identifier, values...) })
+		}
+	}()
 
 	if err := query.checkIdentifier(identifier); err != nil {
 		return err
@@ -431,8 +864,13 @@ func (query *Query) SetFloat64Params(identifier propertyOrAlias, values ...float
 }
 
 // SetBytesParams changes query parameter values on the given property
-func (query *Query) SetBytesParams(identifier propertyOrAlias, values ...[]byte) error {
+func (query *Query) SetBytesParams(identifier propertyOrAlias, values ...[]byte) (err error) {
 	defer runtime.KeepAlive(query)
+	defer func() {
+		if err == nil {
+			query.rememberParams(newParamKey(identifier), func(q *Query) error { return q.SetBytesParams(identifier, values...) })
+		}
+	}()
 
 	if err := query.checkIdentifier(identifier); err != nil {
 		return err
@@ -37,6 +37,7 @@ type QueryBuilder struct {
 	typeId        TypeId
 	innerBuilders []*QueryBuilder
 	orderFlags    map[TypeId]C.OBXOrderFlags
+	orderIds      []TypeId // properties in orderFlags, in the order they were first used
 
 	// The first error that occurred during a any of the calls on the query builder
 	Err error
@@ -106,8 +107,8 @@ func (qb *QueryBuilder) Close() error {
 
 // Build is called internally
 func (qb *QueryBuilder) Build(box *Box) (*Query, error) {
-	for propertyId, orderFlags := range qb.orderFlags {
-		qb.order(C.obx_schema_id(propertyId), orderFlags)
+	for _, propertyId := range qb.orderIds {
+		qb.order(C.obx_schema_id(propertyId), qb.orderFlags[propertyId])
 	}
 
 	if qb.Err != nil {
@@ -115,9 +116,11 @@ func (qb *QueryBuilder) Build(box *Box) (*Query, error) {
 	}
 
 	query := &Query{
-		objectBox: qb.objectBox,
-		box:       box,
-		entity:    qb.objectBox.getEntityById(qb.typeId),
+		objectBox:  qb.objectBox,
+		box:        box,
+		entity:     qb.objectBox.getEntityById(qb.typeId),
+		orderIds:   qb.orderIds,
+		orderFlags: qb.orderFlags,
 	}
 
 	if err := cCallBool(func() bool {
@@ -234,6 +237,10 @@ func (qb *QueryBuilder) order(propertyId C.obx_schema_id, flags C.OBXOrderFlags)
 // if value is true, the flag is set, otherwise the flag is cleared (unset)
 func (qb *QueryBuilder) setOrderFlag(property *BaseProperty, flag C.OBXOrderFlags, value bool) error {
 	if qb.Err == nil && qb.checkEntityId(property.Entity.Id) {
+		if _, exists := qb.orderFlags[property.Id]; !exists {
+			qb.orderIds = append(qb.orderIds, property.Id)
+		}
+
 		if value {
 			// set the flag
 			qb.orderFlags[property.Id] = qb.orderFlags[property.Id] | flag
@@ -576,6 +583,41 @@ func (qb *QueryBuilder) Int64NotIn(property *BaseProperty, values []int64) (Cond
 	return cid, qb.Err
 }
 
+// IntIn is called internally; it combines equality conditions because the native "in" conditions only support
+// Int and Long properties, not the smaller integer types
+func (qb *QueryBuilder) IntIn(property *BaseProperty, values []int64) (ConditionId, error) {
+	if qb.Err == nil && len(values) == 0 {
+		qb.Err = fmt.Errorf("property %d: at least one value is required for In", property.Id)
+	}
+
+	var ids = make([]ConditionId, len(values))
+	for i, value := range values {
+		ids[i], _ = qb.IntEqual(property, value)
+	}
+
+	if qb.Err != nil {
+		return 0, qb.Err
+	}
+	return qb.Any(ids)
+}
+
+// IntNotIn is called internally, see IntIn
+func (qb *QueryBuilder) IntNotIn(property *BaseProperty, values []int64) (ConditionId, error) {
+	if qb.Err == nil && len(values) == 0 {
+		qb.Err = fmt.Errorf("property %d: at least one value is required for NotIn", property.Id)
+	}
+
+	var ids = make([]ConditionId, len(values))
+	for i, value := range values {
+		ids[i], _ = qb.IntNotEqual(property, value)
+	}
+
+	if qb.Err != nil {
+		return 0, qb.Err
+	}
+	return qb.All(ids)
+}
+
 // Int32In is called internally
 func (qb *QueryBuilder) Int32In(property *BaseProperty, values []int32) (ConditionId, error) {
 	var cid ConditionId
@@ -639,6 +681,14 @@ func (qb *QueryBuilder) DoubleBetween(property *BaseProperty, valueA float64, va
 	return cid, qb.Err
 }
 
+// DoubleEqual is called internally; floating point values are matched within the given tolerance
+func (qb *QueryBuilder) DoubleEqual(property *BaseProperty, value float64, tolerance float64) (ConditionId, error) {
+	if qb.Err == nil && tolerance < 0 {
+		qb.Err = fmt.Errorf("property %d: tolerance must not be negative, got %v", property.Id, tolerance)
+	}
+	return qb.DoubleBetween(property, value-tolerance, value+tolerance)
+}
+
 // BytesEqual is called internally
 func (qb *QueryBuilder) BytesEqual(property *BaseProperty, value []byte) (ConditionId, error) {
 	var cid ConditionId
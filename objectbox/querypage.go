@@ -0,0 +1,282 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// QueryPage is a single page of results returned by Query.PageAfter or Query.PageAfterValue
+type QueryPage struct {
+	// Objects is a slice of the matching objects; cast it to the appropriate type (e.g. []*Task)
+	Objects interface{}
+
+	// LastId is the ID of the last object on this page; pass it to PageAfter/PageAfterValue to get the next page
+	LastId uint64
+
+	// LastValue is the order property value of the last object on this page, if the query is ordered by a property;
+	// pass it to PageAfterValue to get the next page. Integers and dates are represented as uint64 (holding the bit
+	// pattern), floats as float64.
+	LastValue interface{}
+
+	// HasNext indicates whether there are more matching objects after this page
+	HasNext bool
+}
+
+// PageAfter returns up to pageSize objects matching the query with an ID greater than lastId, in ascending ID order.
+// Pass lastId=0 to get the first page and then QueryPage.LastId of the previous page to get the following one.
+// As opposed to Offset/Limit, this "keyset" pagination is stable when objects are inserted or removed concurrently.
+// The cursor and the page size are passed to the database, so only the objects on the page are read.
+// Use PageAfterValue for queries ordered by a property. Offset, Limit and MaxResults of the query don't apply.
+func (query *Query) PageAfter(lastId uint64, pageSize uint64) (*QueryPage, error) {
+	order, err := query.pageOrder()
+	if err != nil {
+		return nil, err
+	} else if order != nil {
+		return nil, fmt.Errorf("query is ordered by property %s, use PageAfterValue instead", order.name)
+	}
+
+	var cursor Condition
+	if lastId != 0 {
+		cursor = &conditionClosure{
+			apply: func(qb *QueryBuilder) (ConditionId, error) {
+				return qb.IntGreater(query.idPropertyRef(), int64(lastId), false)
+			},
+		}
+	}
+	return query.page(nil, cursor, nil, lastId, pageSize)
+}
+
+// PageAfterValue is like PageAfter but for queries ordered by a property, e.g. Task_.DateCreated.OrderDesc().
+// It returns up to pageSize objects following the one with the given order property value and ID, in the query
+// order; objects with the same order property value are ordered by ID. Pass nil and 0 to get the first page and then
+// QueryPage.LastValue and QueryPage.LastId of the previous page to get the following one.
+// Only a single order property of an integer, date, floating point or string type is supported; paging fails on an
+// object with a nil order property value.
+func (query *Query) PageAfterValue(lastValue interface{}, lastId uint64, pageSize uint64) (*QueryPage, error) {
+	order, err := query.pageOrder()
+	if err != nil {
+		return nil, err
+	} else if order == nil {
+		return nil, errors.New("query isn't ordered by a property, use PageAfter instead")
+	}
+
+	value, err := order.patchValue(lastValue)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor Condition
+	if value != nil || lastId != 0 {
+		if value == nil {
+			return nil, fmt.Errorf("order property %s value must be given with the last ID", order.name)
+		} else if cursor, err = query.orderCursor(order, value, lastId); err != nil {
+			return nil, err
+		}
+	}
+	return query.page(order, cursor, value, lastId, pageSize)
+}
+
+// pageOrder returns the property the query is ordered by, or nil if it's in the (default) ascending ID order
+func (query *Query) pageOrder() (*property, error) {
+	if len(query.orderIds) == 0 {
+		return nil, nil
+	} else if len(query.orderIds) > 1 {
+		return nil, errors.New("paging a query ordered by multiple properties is not supported")
+	}
+
+	var prop = query.entity.property(query.orderIds[0])
+	if prop == nil {
+		return nil, fmt.Errorf("order property %d not found in entity %s", query.orderIds[0], query.entity.name)
+	} else if prop.flags&C.OBXPropertyFlags_ID != 0 && query.orderFlags[prop.id]&C.OBXOrderFlags_DESCENDING == 0 {
+		return nil, nil
+	}
+	return prop, nil
+}
+
+func (query *Query) idPropertyRef() *BaseProperty {
+	return &BaseProperty{Id: query.entity.idProperty().id, Entity: &Entity{Id: query.entity.id}}
+}
+
+// orderCursor creates a condition matching objects after the given one: "order > value OR (order == value AND id > lastId)"
+func (query *Query) orderCursor(order *property, value interface{}, lastId uint64) (Condition, error) {
+	var flags = query.orderFlags[order.id]
+	var descending = flags&C.OBXOrderFlags_DESCENDING != 0
+	var prop = &BaseProperty{Id: order.id, Entity: &Entity{Id: query.entity.id}}
+
+	var after, equal func(qb *QueryBuilder) (ConditionId, error)
+	switch order.propertyType {
+	case C.OBXPropertyType_Byte, C.OBXPropertyType_Char, C.OBXPropertyType_Short, C.OBXPropertyType_Int,
+		C.OBXPropertyType_Long, C.OBXPropertyType_Date, C.OBXPropertyType_DateNano, C.OBXPropertyType_Relation:
+		var v = int64(value.(uint64))
+		after = func(qb *QueryBuilder) (ConditionId, error) {
+			if descending {
+				return qb.IntLess(prop, v, false)
+			}
+			return qb.IntGreater(prop, v, false)
+		}
+		equal = func(qb *QueryBuilder) (ConditionId, error) { return qb.IntEqual(prop, v) }
+	case C.OBXPropertyType_Float, C.OBXPropertyType_Double:
+		var v = value.(float64)
+		after = func(qb *QueryBuilder) (ConditionId, error) {
+			if descending {
+				return qb.DoubleLess(prop, v, false)
+			}
+			return qb.DoubleGreater(prop, v, false)
+		}
+		equal = func(qb *QueryBuilder) (ConditionId, error) { return qb.DoubleBetween(prop, v, v) }
+	case C.OBXPropertyType_String:
+		var v = value.(string)
+		var caseSensitive = flags&C.OBXOrderFlags_CASE_SENSITIVE != 0
+		after = func(qb *QueryBuilder) (ConditionId, error) {
+			if descending {
+				return qb.StringLess(prop, v, caseSensitive, false)
+			}
+			return qb.StringGreater(prop, v, caseSensitive, false)
+		}
+		equal = func(qb *QueryBuilder) (ConditionId, error) { return qb.StringEquals(prop, v, caseSensitive) }
+	default:
+		return nil, fmt.Errorf("paging by property %s of type %d is not supported", order.name, order.propertyType)
+	}
+
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			cidAfter, err := after(qb)
+			if err != nil {
+				return 0, err
+			}
+
+			cidEqual, err := equal(qb)
+			if err != nil {
+				return 0, err
+			}
+
+			cidIdAfter, err := qb.IntGreater(query.idPropertyRef(), int64(lastId), false)
+			if err != nil {
+				return 0, err
+			}
+
+			cidTie, err := qb.All([]ConditionId{cidEqual, cidIdAfter})
+			if err != nil {
+				return 0, err
+			}
+			return qb.Any([]ConditionId{cidAfter, cidTie})
+		},
+	}, nil
+}
+
+// page reads a single page using a query derived from this one, with the given cursor condition and a limit.
+// The last value and ID are returned in the page if it's empty.
+func (query *Query) page(order *property, cursor Condition, lastValue interface{}, lastId uint64, pageSize uint64) (*QueryPage, error) {
+	if pageSize == 0 {
+		return nil, errors.New("page size must be greater than zero")
+	}
+
+	defer runtime.KeepAlive(query)
+
+	if err := query.checkForRead(); err != nil {
+		return nil, err
+	}
+
+	var conditions = query.conditions[:len(query.conditions):len(query.conditions)]
+	if cursor != nil {
+		conditions = append(conditions, cursor)
+	}
+	if order != nil && order.flags&C.OBXPropertyFlags_ID == 0 {
+		// objects with the same order property value are ordered by ID, matching the cursor condition
+		conditions = append(conditions, query.idPropertyRef().orderAsc())
+	}
+
+	pageQuery, err := query.box.buildQuery(conditions)
+	if err != nil {
+		return nil, err
+	}
+	defer pageQuery.Close()
+
+	if err := query.applyParams(pageQuery); err != nil {
+		return nil, err
+	} else if err := pageQuery.Limit(pageSize + 1).check(); err != nil {
+		return nil, err
+	}
+
+	var page = &QueryPage{LastId: lastId, LastValue: lastValue}
+	var binding = query.entity.binding
+	var idProperty = query.entity.idProperty()
+	var count uint64
+
+	visitor, err := dataVisitorRegister(func(bytes []byte) bool {
+		if count++; count > pageSize {
+			page.HasNext = true
+			return false
+		}
+
+		var table = rawTable(bytes)
+		id, err2 := idProperty.read(table)
+		if err2 == nil && order != nil {
+			if page.LastValue, err2 = order.read(table); err2 == nil && page.LastValue == nil {
+				err2 = fmt.Errorf("object %v has a nil value of the order property %s", id, order.name)
+			}
+		}
+
+		var object interface{}
+		if err2 == nil {
+			object, err2 = query.entity.load(query.objectBox, bytes)
+		}
+
+		if err2 != nil {
+			err = err2
+			return false
+		}
+
+		page.LastId = id.(uint64)
+		page.Objects = binding.AppendToSlice(page.Objects, object)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer dataVisitorUnregister(visitor)
+
+	if pageSize < defaultSliceCapacity {
+		page.Objects = binding.MakeSlice(int(pageSize))
+	} else {
+		page.Objects = binding.MakeSlice(defaultSliceCapacity)
+	}
+
+	// use another `error` variable as `err` may be set by the visitor callback above
+	var err2 = query.objectBox.RunInReadTx(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_query_visit(pageQuery.cQuery, dataVisitor, unsafe.Pointer(&visitor))
+		})
+	})
+	runtime.KeepAlive(pageQuery)
+
+	if err2 != nil {
+		return nil, err2
+	} else if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+// QueryPipeline processes the objects found by a query with Go functions while they're read, see Query.Filter().
+type QueryPipeline struct {
+	query *Query
+	steps []pipelineStep
+}
+
+// pipelineStep is either a filter or a transformation
+type pipelineStep struct {
+	filter    func(object interface{}) bool
+	transform func(object interface{}) interface{}
+}
+
+// Filter creates a pipeline passing on only the objects for which the given function returns true, e.g. to check
+// complex business rules which can't be expressed as query conditions. The objects are filtered one by one while
+// they're read, so the non-matching ones don't stay in memory.
+// Offset() and Limit() set on the query apply to the pipeline results, i.e. after filtering.
+func (query *Query) Filter(fn func(object interface{}) bool) *QueryPipeline {
+	return (&QueryPipeline{query: query}).Filter(fn)
+}
+
+// Map creates a pipeline replacing each object found by the query with the result of the given function,
+// e.g. to extract only the fields you need. See Filter() for more details.
+func (query *Query) Map(fn func(object interface{}) interface{}) *QueryPipeline {
+	return (&QueryPipeline{query: query}).Map(fn)
+}
+
+// Filter adds a step passing on only the values for which the given function returns true
+func (pipeline *QueryPipeline) Filter(fn func(value interface{}) bool) *QueryPipeline {
+	pipeline.steps = append(pipeline.steps, pipelineStep{filter: fn})
+	return pipeline
+}
+
+// Map adds a step replacing each value with the result of the given function
+func (pipeline *QueryPipeline) Map(fn func(value interface{}) interface{}) *QueryPipeline {
+	pipeline.steps = append(pipeline.steps, pipelineStep{transform: fn})
+	return pipeline
+}
+
+// Visit calls the given function with each result of the pipeline; return false from the function to stop
+func (pipeline *QueryPipeline) Visit(fn func(result interface{}) bool) error {
+	var query = pipeline.query
+	var offset = query.offset
+	var limit = query.limit
+	var count uint64
+
+	var err error
+	var err2 = query.withoutRange(func() error {
+		return query.FindRaw(func(bytes []byte) bool {
+			object, err3 := query.entity.load(query.objectBox, bytes)
+			if err3 != nil {
+				err = err3
+				return false
+			}
+
+			var value, passed = pipeline.process(object)
+			if !passed {
+				return true
+			} else if offset > 0 {
+				offset--
+				return true
+			}
+
+			count++
+			return fn(value) && (limit == 0 || count < limit)
+		})
+	})
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
+
+// process runs the steps on the given object; returns false if it was filtered out
+func (pipeline *QueryPipeline) process(value interface{}) (interface{}, bool) {
+	for _, step := range pipeline.steps {
+		if step.filter != nil {
+			if !step.filter(value) {
+				return nil, false
+			}
+		} else {
+			value = step.transform(value)
+		}
+	}
+	return value, true
+}
+
+// Find returns all results of the pipeline. Unless there's a Map() step, these are the objects (pointers) themselves.
+func (pipeline *QueryPipeline) Find() (results []interface{}, err error) {
+	err = pipeline.Visit(func(result interface{}) bool {
+		results = append(results, result)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Count returns the number of results of the pipeline
+func (pipeline *QueryPipeline) Count() (count uint64, err error) {
+	err = pipeline.Visit(func(result interface{}) bool {
+		count++
+		return true
+	})
+	return count, err
+}
+
+// withoutRange runs fn with the native query offset and limit removed, and restores them afterwards.
+func (query *Query) withoutRange(fn func() error) error {
+	if query.offset == 0 && query.limit == 0 {
+		return fn()
+	}
+
+	if err := cCall(func() C.obx_err { return C.obx_query_offset_limit(query.cQuery, 0, 0) }); err != nil {
+		return err
+	}
+
+	var err = fn()
+
+	// restore the offset and limit set by the user
+	if errRange := cCall(func() C.obx_err {
+		return C.obx_query_offset_limit(query.cQuery, C.size_t(query.offset), C.size_t(query.limit))
+	}); err == nil {
+		err = errRange
+	}
+	return err
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// RegexpQuery finds objects with a string property matching a regular expression, see Box.QueryRegexp()
+type RegexpQuery struct {
+	query  *Query
+	prop   *property
+	regexp *regexp.Regexp
+	prefix string
+}
+
+// QueryRegexp creates a query for objects matching the given conditions whose property value matches the regular
+// expression (Go syntax, see package regexp). ObjectBox doesn't support regular expressions natively, so the objects
+// found by the conditions are filtered while they're read, without loading the non-matching ones.
+// If the pattern is anchored at the start and begins with a literal text (e.g. "^img_[0-9]+$"), a case-sensitive
+// HasPrefix condition is added, narrowing the objects to filter already in the native query.
+// Close the query once you don't need it anymore.
+func (box *Box) QueryRegexp(property *PropertyString, pattern string, conditions ...Condition) (*RegexpQuery, error) {
+	if property == nil || property.BaseProperty == nil || property.Entity == nil {
+		return nil, errors.New("property is not defined")
+	}
+
+	var prop = box.entity.property(property.Id)
+	if property.Entity.Id != box.entity.id || prop == nil {
+		return nil, fmt.Errorf("property %d doesn't belong to entity %s", property.Id, box.entity.name)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefix = regexpAnchoredPrefix(pattern)
+	if len(prefix) > 0 {
+		conditions = append(conditions, property.HasPrefix(prefix, true))
+	}
+
+	query, err := box.QueryOrError(conditions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegexpQuery{query: query, prop: prop, regexp: re, prefix: prefix}, nil
+}
+
+// regexpAnchoredPrefix returns the literal text all matches of the pattern start with, if it's anchored at the start.
+// Case-insensitive literals end the prefix, so it can be matched case-sensitively.
+func regexpAnchoredPrefix(pattern string) string {
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+
+	var parts = []*syntax.Regexp{parsed}
+	if parsed.Op == syntax.OpConcat {
+		parts = parsed.Sub
+	}
+	if len(parts) == 0 || parts[0].Op != syntax.OpBeginText {
+		return ""
+	}
+
+	var prefix []rune
+	for _, part := range parts[1:] {
+		if part.Op != syntax.OpLiteral || part.Flags&syntax.FoldCase != 0 {
+			break
+		}
+		prefix = append(prefix, part.Rune...)
+	}
+	return string(prefix)
+}
+
+// Prefix returns the literal prefix used to narrow the native query, or an empty string if none is used
+func (rq *RegexpQuery) Prefix() string {
+	return rq.prefix
+}
+
+// Query returns the underlying native query, e.g. to set parameters or to configure its offset and limit, which are
+// applied to the objects matching the regular expression
+func (rq *RegexpQuery) Query() *Query {
+	return rq.query
+}
+
+// Close frees (native) resources held by the underlying query
+func (rq *RegexpQuery) Close() error {
+	return rq.query.Close()
+}
+
+// Visit calls the given function with each matching object; return false from the function to stop
+func (rq *RegexpQuery) Visit(fn func(object interface{}) bool) error {
+	var err error
+	var err2 = rq.visitRaw(func(bytes []byte) bool {
+		object, err3 := rq.query.entity.load(rq.query.objectBox, bytes)
+		if err3 != nil {
+			err = err3
+			return false
+		}
+		return fn(object)
+	})
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
+
+// Find returns all matching objects as a slice of the entity type (pointers to objects)
+func (rq *RegexpQuery) Find() (objects interface{}, err error) {
+	var binding = rq.query.entity.binding
+	objects = binding.MakeSlice(defaultSliceCapacity)
+	err = rq.Visit(func(object interface{}) bool {
+		objects = binding.AppendToSlice(objects, object)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// FindIds returns IDs of all matching objects
+func (rq *RegexpQuery) FindIds() (ids []uint64, err error) {
+	var idProp = rq.query.entity.idProperty()
+	if idProp == nil {
+		return nil, fmt.Errorf("entity %s has no ID property", rq.query.entity.name)
+	}
+
+	var err2 error
+	err = rq.visitRaw(func(bytes []byte) bool {
+		id, err3 := idProp.read(rawTable(bytes))
+		if err3 != nil {
+			err2 = err3
+			return false
+		}
+		ids = append(ids, id.(uint64))
+		return true
+	})
+	if err == nil {
+		err = err2
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Count returns the number of matching objects
+func (rq *RegexpQuery) Count() (count uint64, err error) {
+	err = rq.visitRaw(func(bytes []byte) bool {
+		count++
+		return true
+	})
+	return count, err
+}
+
+// visitRaw calls the given function with the FlatBuffers data of each object matching the regular expression.
+// The offset and limit of the native query are applied to the matching objects, i.e. after filtering.
+func (rq *RegexpQuery) visitRaw(fn func(bytes []byte) bool) error {
+	var query = rq.query
+	var offset = query.offset
+	var limit = query.limit
+	var count uint64
+
+	var err error
+	var err2 = query.withoutRange(func() error {
+		return query.FindRaw(func(bytes []byte) bool {
+			value, err3 := rq.prop.read(rawTable(bytes))
+			if err3 != nil {
+				err = err3
+				return false
+			} else if value == nil || !rq.regexp.MatchString(value.(string)) {
+				return true
+			} else if offset > 0 {
+				offset--
+				return true
+			}
+
+			count++
+			return fn(bytes) && (limit == 0 || count < limit)
+		})
+	})
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
+
+func rawTable(bytes []byte) *flatbuffers.Table {
+	return &flatbuffers.Table{Bytes: bytes, Pos: flatbuffers.GetUOffsetT(bytes)}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// FindSorted returns all objects matching the query, sorted in memory using the given function, e.g. by computed
+// keys which the native ordering (OrderAsc/OrderDesc conditions) can't express. Objects comparing equal keep the order
+// of the query. Use LessBy() to sort by multiple keys.
+//
+// If Limit() is set, only the best offset+limit objects are kept in memory while reading (using a bounded heap),
+// i.e. the query returns the top-N efficiently. Offset() and Limit() apply to the sorted result.
+func (query *Query) FindSorted(less func(a, b interface{}) bool) (objects interface{}, err error) {
+	var sorted = &sortedObjects{less: less}
+	var bound uint64 // 0 = keep all
+	if query.limit > 0 {
+		bound = query.offset + query.limit
+	}
+
+	var seq uint64
+	var errLoad error
+	err = query.withoutRange(func() error {
+		return query.FindRaw(func(bytes []byte) bool {
+			object, err := query.entity.load(query.objectBox, bytes)
+			if err != nil {
+				errLoad = err
+				return false
+			}
+
+			var entry = sortedEntry{object: object, seq: seq}
+			seq++
+
+			if bound == 0 {
+				sorted.entries = append(sorted.entries, entry)
+			} else if uint64(sorted.Len()) < bound {
+				heap.Push(sorted, entry)
+			} else if sorted.before(entry, sorted.entries[0]) {
+				// replace the worst object kept so far
+				sorted.entries[0] = entry
+				heap.Fix(sorted, 0)
+			}
+			return true
+		})
+	})
+	if err == nil {
+		err = errLoad
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(sorted.entries, func(i, j int) bool {
+		return sorted.before(sorted.entries[i], sorted.entries[j])
+	})
+
+	var binding = query.entity.binding
+	objects = binding.MakeSlice(len(sorted.entries))
+	for i, entry := range sorted.entries {
+		if uint64(i) >= query.offset {
+			objects = binding.AppendToSlice(objects, entry.object)
+		}
+	}
+	return objects, nil
+}
+
+// LessBy combines the given functions to sort by multiple keys: objects equal according to the first one are sorted
+// by the second one, etc. Use the result with Query.FindSorted() or sort.Slice().
+func LessBy(less ...func(a, b interface{}) bool) func(a, b interface{}) bool {
+	return func(a, b interface{}) bool {
+		for _, fn := range less {
+			if fn(a, b) {
+				return true
+			} else if fn(b, a) {
+				return false
+			}
+		}
+		return false
+	}
+}
+
+type sortedEntry struct {
+	object interface{}
+	seq    uint64 // position in the query result, to keep the order of equal objects
+}
+
+// sortedObjects implements heap.Interface as a max-heap, i.e. the root is the object which would be sorted last
+type sortedObjects struct {
+	entries []sortedEntry
+	less    func(a, b interface{}) bool
+}
+
+// before returns true if a is sorted before b
+func (s *sortedObjects) before(a, b sortedEntry) bool {
+	if s.less(a.object, b.object) {
+		return true
+	} else if s.less(b.object, a.object) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (s *sortedObjects) Len() int           { return len(s.entries) }
+func (s *sortedObjects) Less(i, j int) bool { return s.before(s.entries[j], s.entries[i]) }
+func (s *sortedObjects) Swap(i, j int)      { s.entries[i], s.entries[j] = s.entries[j], s.entries[i] }
+
+func (s *sortedObjects) Push(x interface{}) {
+	s.entries = append(s.entries, x.(sortedEntry))
+}
+
+func (s *sortedObjects) Pop() interface{} {
+	var last = s.entries[len(s.entries)-1]
+	s.entries = s.entries[:len(s.entries)-1]
+	return last
+}
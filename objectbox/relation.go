@@ -16,6 +16,11 @@
 
 package objectbox
 
+/*
+#include "objectbox.h"
+*/
+import "C"
+
 import (
 	"fmt"
 )
@@ -162,3 +167,89 @@ func (relation *RelationToMany) Link(conditions ...Condition) Condition {
 }
 
 // TODO contains() would make sense for many-to-many (slice)
+
+// CountEquals finds source objects with exactly the given number of related target objects.
+// The matching objects are determined once, when the query is built; see CountGreaterThan().
+func (relation *RelationToMany) CountEquals(count uint64) Condition {
+	return relation.countCondition(func(c uint64) bool { return c == count })
+}
+
+// CountGreaterThan finds source objects with more than the given number of related target objects.
+// ObjectBox can't count relations in a query natively, so the IDs of the matching source objects are collected when
+// the query is built (reading the relations of all source objects) and the condition matches those IDs.
+// They are NOT evaluated again by Find(), Count() etc., so the query doesn't reflect objects or relations added,
+// changed or removed after it was built; build a new query (or use Box.Query() for each search) in that case.
+func (relation *RelationToMany) CountGreaterThan(count uint64) Condition {
+	return relation.countCondition(func(c uint64) bool { return c > count })
+}
+
+// CountLessThan finds source objects with fewer than the given number of related target objects.
+// The matching objects are determined once, when the query is built; see CountGreaterThan().
+func (relation *RelationToMany) CountLessThan(count uint64) Condition {
+	return relation.countCondition(func(c uint64) bool { return c < count })
+}
+
+// CountBetween finds source objects with the number of related target objects in the given range (inclusive).
+// The matching objects are determined once, when the query is built; see CountGreaterThan().
+func (relation *RelationToMany) CountBetween(min, max uint64) Condition {
+	return relation.countCondition(func(c uint64) bool { return c >= min && c <= max })
+}
+
+// countCondition matches the IDs of the source objects whose relation count matches at the time the query is built
+func (relation *RelationToMany) countCondition(matches func(count uint64) bool) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			var entity = qb.objectBox.entitiesById[relation.Source.Id]
+			if entity == nil {
+				return 0, fmt.Errorf("unknown relation source entity %d", relation.Source.Id)
+			}
+
+			var idProp = entity.idProperty()
+			if idProp == nil {
+				return 0, fmt.Errorf("entity %s has no ID property", entity.name)
+			}
+			var idProperty = &BaseProperty{Id: idProp.id, Entity: relation.Source}
+
+			ids, err := relation.sourceIdsByCount(qb.objectBox, matches)
+			if err != nil {
+				return 0, err
+			} else if len(ids) == 0 {
+				return qb.IntEqual(idProperty, 0) // IDs start at 1, i.e. this doesn't match any object
+			}
+			return qb.Int64In(idProperty, ids)
+		},
+	}
+}
+
+// sourceIdsByCount returns IDs of the source objects whose number of related target objects matches
+func (relation *RelationToMany) sourceIdsByCount(ob *ObjectBox, matches func(count uint64) bool) ([]int64, error) {
+	sourceBox, err := ob.box(relation.Source.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []int64
+	err = ob.RunInReadTx(func() error {
+		query, err := sourceBox.QueryOrError()
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+
+		sourceIds, err := query.FindIds()
+		if err != nil {
+			return err
+		}
+
+		for _, id := range sourceIds {
+			targetIds, err := sourceBox.RelationIds(relation, id)
+			if err != nil {
+				return err
+			} else if matches(uint64(len(targetIds))) {
+				result = append(result, int64(id))
+			}
+		}
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import "time"
+
+// RetryPolicy configures how beginning a transaction is retried if it fails with a transient error,
+// i.e. ErrMaxReadersExceeded when there are too many concurrent readers. See Builder.RetryPolicy().
+// Retries use exponential backoff: the delay starts at InitialDelay and doubles with each attempt, up to MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one; 0 and 1 disable retrying
+	MaxAttempts uint
+
+	// InitialDelay is the delay before the first retry
+	InitialDelay time.Duration
+
+	// MaxDelay limits the delay between two attempts; 0 means no limit
+	MaxDelay time.Duration
+}
+
+// retryDelay returns the delay before the next attempt, or false if the failed attempt shouldn't be retried
+func (policy RetryPolicy) retryDelay(attempt uint, err error) (time.Duration, bool) {
+	if attempt >= policy.MaxAttempts || !isRetryableError(err) {
+		return 0, false
+	}
+
+	var delay = policy.InitialDelay
+	for i := uint(1); i < attempt; i++ {
+		delay *= 2
+		if policy.MaxDelay > 0 && delay >= policy.MaxDelay {
+			break
+		}
+	}
+
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	return delay, true
+}
+
+// isRetryableError reports whether the given error is transient, i.e. the operation may succeed if tried again
+func isRetryableError(err error) bool {
+	if obxErr, ok := err.(*Error); ok {
+		return obxErr.code == C.OBX_ERROR_MAX_READERS_EXCEEDED
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// Sequence provides named, monotonically increasing counters independent of object IDs, e.g. for invoice numbers.
+// The counters are stored in a dedicated entity (see "Dedicated entities" in the package documentation), e.g.:
+//
+//	type Counter struct {
+//		Id    uint64
+//		Name  string `objectbox:"unique"`
+//		Value int64
+//	}
+//
+// and the sequence is created using the generated properties:
+//
+//	sequence, err := ob.NewSequence(Counter_.Name, Counter_.Value)
+//	invoiceNumber, err := sequence.NextValue("invoices")
+type Sequence struct {
+	entity *dedicatedEntity
+	name   *property
+	value  *property
+
+	nameProperty *PropertyString
+}
+
+// NewSequence creates a sequence storing a counter per name in the entity the given properties belong to.
+func (ob *ObjectBox) NewSequence(name *PropertyString, value *PropertyInt64) (*Sequence, error) {
+	if name == nil || name.BaseProperty == nil {
+		return nil, errors.New("sequence name property is not defined")
+	} else if value == nil || value.BaseProperty == nil {
+		return nil, errors.New("sequence value property is not defined")
+	}
+
+	entity, err := ob.dedicatedEntity(name.entityId())
+	if err != nil {
+		return nil, err
+	}
+
+	var sequence = &Sequence{
+		entity:       entity,
+		name:         entity.property(name.BaseProperty),
+		value:        entity.property(value.BaseProperty),
+		nameProperty: name,
+	}
+	if sequence.name == nil || sequence.name.propertyType != C.OBXPropertyType_String {
+		return nil, fmt.Errorf("sequence name property %d must be a string property of entity %s",
+			name.propertyId(), entity.box.entity.name)
+	} else if sequence.value == nil || sequence.value.propertyType != C.OBXPropertyType_Long {
+		return nil, fmt.Errorf("sequence value property %d must be an int64 property of entity %s",
+			value.propertyId(), entity.box.entity.name)
+	}
+
+	return sequence, nil
+}
+
+// NextValue increments the counter with the given name and returns the new value; the first value is 1.
+// The counter is incremented inside a write transaction: call it inside RunInWriteTx() to use the value in the same
+// transaction, e.g. when storing an invoice; if that transaction is rolled back, the value is not used up.
+func (sequence *Sequence) NextValue(name string) (value int64, err error) {
+	err = sequence.entity.box.ObjectBox.RunInWriteTx(func() error {
+		id, current, err := sequence.read(name)
+		if err != nil {
+			return err
+		}
+
+		value = current + 1
+		_, err = sequence.entity.put(id, map[TypeId]interface{}{
+			sequence.name.id:  name,
+			sequence.value.id: uint64(value),
+		}, len(name)+64, cPutModePut)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// Value returns the current value of the counter with the given name, i.e. the last one returned by NextValue(),
+// or 0 if it hasn't been incremented yet
+func (sequence *Sequence) Value(name string) (value int64, err error) {
+	err = sequence.entity.box.ObjectBox.RunInReadTx(func() error {
+		_, value, err = sequence.read(name)
+		return err
+	})
+	return value, err
+}
+
+// read returns the ID of the object holding the counter with the given name and its value; the ID is 0 if there's
+// no such object yet. Must be called inside a transaction.
+func (sequence *Sequence) read(name string) (id uint64, value int64, err error) {
+	query, err := sequence.entity.box.buildQuery([]Condition{sequence.nameProperty.Equals(name, true)})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer query.Close()
+
+	err = sequence.entity.visit(query, func(table *flatbuffers.Table) (bool, error) {
+		objectId, err := sequence.entity.id.read(table)
+		if err != nil {
+			return false, err
+		}
+		objectValue, err := sequence.value.read(table)
+		if err != nil {
+			return false, err
+		}
+
+		id = objectId.(uint64)
+		if objectValue != nil {
+			value = int64(objectValue.(uint64))
+		}
+		return false, nil // there's a single object per name
+	})
+
+	if err != nil {
+		return 0, 0, err
+	}
+	return id, value, nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// SoftDelete configures the entity the given property belongs to, to mark objects as deleted instead of removing them.
+// The property (an int64 or a date field, e.g. a time.Time tagged `objectbox:"date"`) holds the time of deletion in
+// milliseconds since the Unix epoch; a zero or nil value means the object isn't deleted. Call it once per entity.
+// With soft delete enabled:
+//   - Box.RemoveId, RemoveIds, Remove and Query.Remove set the property instead of removing the objects,
+//   - queries created by Box.Query/QueryOrError exclude deleted objects, see Box.QueryIncludingDeleted,
+//   - Box.RemoveHard, RestoreId and PurgeDeletedBefore complete the lifecycle.
+//
+// Reading objects by ID (Get, GetMany, GetAll), Count and RemoveAll work on all stored objects, including deleted ones;
+// use a query to read only the objects that aren't deleted.
+func (builder *Builder) SoftDelete(deletedAt *PropertyInt64) *Builder {
+	if builder.Error != nil {
+		return builder
+	}
+
+	if deletedAt == nil || deletedAt.BaseProperty == nil || deletedAt.Entity == nil {
+		builder.Error = errors.New("soft delete property is not defined")
+	} else if builder.softDelete[deletedAt.entityId()] != nil {
+		builder.Error = fmt.Errorf("soft delete is already configured for entity %d", deletedAt.entityId())
+	} else {
+		if builder.softDelete == nil {
+			builder.softDelete = make(map[TypeId]*PropertyInt64)
+		}
+		builder.softDelete[deletedAt.entityId()] = deletedAt
+	}
+	return builder
+}
+
+// checkSoftDeleteProperty checks the property configured by Builder.SoftDelete() is an int64 or a date in the model
+func checkSoftDeleteProperty(model *Model, deletedAt *PropertyInt64) error {
+	var entity = model.entitiesById[deletedAt.entityId()]
+	if entity == nil {
+		return fmt.Errorf("entity %d is not part of the model", deletedAt.entityId())
+	}
+
+	var prop = entity.property(deletedAt.propertyId())
+	if prop == nil {
+		return fmt.Errorf("property %d doesn't belong to entity %s", deletedAt.propertyId(), entity.name)
+	} else if prop.propertyType != C.OBXPropertyType_Date && prop.propertyType != C.OBXPropertyType_Long {
+		return fmt.Errorf("soft delete property %s must be a date or int64", prop.name)
+	}
+	return nil
+}
+
+// QueryIncludingDeleted is like QueryOrError() but doesn't exclude objects marked as deleted, see Builder.SoftDelete
+func (box *Box) QueryIncludingDeleted(conditions ...Condition) (*Query, error) {
+	return box.buildQuery(conditions)
+}
+
+// RemoveHard removes the objects with the given IDs from the database, regardless of Builder.SoftDelete().
+// Returns the number of removed objects.
+func (box *Box) RemoveHard(ids ...uint64) (count uint64, err error) {
+	var obs = box.ObjectBox.observe(OperationRemove, box.entity)
+	count, err = box.removeIds(ids)
+	obs.done(int(count), err)
+	return count, err
+}
+
+// RestoreId clears the deletion mark of the object with the given ID, see Builder.SoftDelete().
+// Returns false if there's no such object.
+func (box *Box) RestoreId(id uint64) (bool, error) {
+	if box.softDelete == nil {
+		return false, fmt.Errorf("soft delete is not enabled for entity %s", box.entity.name)
+	}
+	return box.Patch(id, Set(box.softDelete, nil))
+}
+
+// PurgeDeletedBefore removes the objects marked as deleted before the given time from the database.
+// Returns the number of removed objects.
+func (box *Box) PurgeDeletedBefore(before time.Time) (count uint64, err error) {
+	if box.softDelete == nil {
+		return 0, fmt.Errorf("soft delete is not enabled for entity %s", box.entity.name)
+	}
+
+	var beforeMs = before.UnixNano() / int64(time.Millisecond)
+	if beforeMs <= 1 {
+		return 0, nil
+	}
+
+	query, err := box.QueryIncludingDeleted(box.softDelete.Between(1, beforeMs-1))
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		ids, err := query.findIds()
+		if err == nil && len(ids) > 0 {
+			count, err = box.RemoveHard(ids...)
+		}
+		return err
+	})
+	return count, err
+}
+
+// notDeleted is a condition matching objects that aren't marked as deleted
+func (box *Box) notDeleted() Condition {
+	return Any(box.softDelete.IsNil(), box.softDelete.Equals(0))
+}
+
+// softRemoveIds marks the given objects as deleted; returns the number of objects that weren't deleted before
+func (box *Box) softRemoveIds(ids []uint64) (count uint64, err error) {
+	var now = time.Now().UnixNano() / int64(time.Millisecond)
+	var prop = box.entity.property(box.softDelete.propertyId())
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		for _, id := range ids {
+			var deleted bool
+			found, err := box.GetRaw(id, func(bytes []byte) error {
+				value, err := prop.read(&flatbuffers.Table{Bytes: bytes, Pos: flatbuffers.GetUOffsetT(bytes)})
+				deleted = value != nil && value.(uint64) != 0
+				return err
+			})
+			if err != nil {
+				return err
+			} else if !found || deleted {
+				continue
+			}
+
+			if _, err := box.Patch(id, Set(box.softDelete, now)); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
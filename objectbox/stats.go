@@ -0,0 +1,75 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// StoreStats provides information about the database, see ObjectBox.Stats()
+type StoreStats struct {
+	// EntityCounts maps entity names to the number of stored objects
+	EntityCounts map[string]uint64
+
+	// SizeOnDisk is the size of the database file in bytes; 0 for in-memory databases
+	SizeOnDisk int64
+}
+
+// Stats collects the number of objects of each entity (in a single read transaction) and the database size on disk.
+func (ob *ObjectBox) Stats() (*StoreStats, error) {
+	var stats = &StoreStats{
+		EntityCounts: make(map[string]uint64, len(ob.entitiesByName)),
+	}
+
+	var err = ob.RunInReadTx(func() error {
+		for name, entity := range ob.entitiesByName {
+			box, err := ob.box(entity.id)
+			if err != nil {
+				return err
+			}
+
+			if stats.EntityCounts[name], err = box.Count(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.SizeOnDisk, err = ob.SizeOnDisk(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// SizeOnDisk returns the size of the database file in bytes; 0 for in-memory databases.
+// Note: the file size includes the space reserved for future writes, i.e. it doesn't shrink when objects are removed.
+func (ob *ObjectBox) SizeOnDisk() (int64, error) {
+	if strings.HasPrefix(ob.directory, inMemoryPrefix) {
+		return 0, nil
+	}
+
+	info, err := os.Stat(filepath.Join(ob.directory, dataFileName))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,306 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StoreOpener opens the store identified by the given key, e.g. a tenant ID, see StoreManager.
+// Each call must use a new model, i.e. call the generated ObjectBoxModel() function instead of reusing its result:
+//
+//	func(tenant string) (*objectbox.ObjectBox, error) {
+//		return objectbox.NewBuilder().Directory(filepath.Join("db", tenant)).Model(ObjectBoxModel()).BuildOrError()
+//	}
+type StoreOpener func(key string) (*ObjectBox, error)
+
+// StoreManager keeps multiple stores (e.g. one per tenant) open, opening them on demand.
+// The number of open stores can be limited, closing the least recently used idle one when a new store is needed,
+// and stores that haven't been used for a while can be closed automatically.
+// A store is "in use" between Acquire() and Release(); stores in use are never closed by the manager.
+// Stores are opened and closed without holding the manager's lock, so a slow open doesn't block access to other stores.
+type StoreManager struct {
+	open          StoreOpener
+	maxOpenStores int
+	idleTimeout   time.Duration
+
+	mutex   sync.Mutex
+	stores  map[string]*list.Element // values are *managedStore, including the ones being opened
+	lru     *list.List               // front = most recently used
+	closing map[string]chan struct{} // stores being closed; the channel is closed when done
+	closed  bool
+	pending sync.WaitGroup // opening and closing stores, running without the mutex
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+type managedStore struct {
+	key      string
+	ob       *ObjectBox
+	err      error
+	opened   chan struct{} // closed after the store was opened (or failed to open), then ob or err is set
+	refs     int
+	lastUsed time.Time
+}
+
+// NewStoreManager creates a manager opening stores using the given function.
+// maxOpenStores limits the number of stores open at the same time; 0 means no limit.
+// Stores not used for idleTimeout are closed automatically; 0 disables closing on idle.
+// Call Close() when the manager isn't needed anymore; it closes all the stores.
+func NewStoreManager(open StoreOpener, maxOpenStores int, idleTimeout time.Duration) (*StoreManager, error) {
+	if open == nil {
+		return nil, errors.New("store opener is not defined")
+	} else if maxOpenStores < 0 {
+		return nil, errors.New("max open stores must not be negative")
+	} else if idleTimeout < 0 {
+		return nil, errors.New("idle timeout must not be negative")
+	}
+
+	var manager = &StoreManager{
+		open:          open,
+		maxOpenStores: maxOpenStores,
+		idleTimeout:   idleTimeout,
+		stores:        make(map[string]*list.Element),
+		lru:           list.New(),
+		closing:       make(map[string]chan struct{}),
+		stop:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+
+	if idleTimeout > 0 {
+		go manager.closeIdleLoop()
+	} else {
+		close(manager.stopped)
+	}
+
+	return manager, nil
+}
+
+// Acquire returns the store with the given key, opening it if necessary.
+// The store is marked as in use until the matching Release() call; don't use it after releasing.
+// Fails if the store would exceed the limit of open stores and all the other stores are in use.
+// Concurrent calls for a store that's just being opened wait for it to open.
+func (manager *StoreManager) Acquire(key string) (*ObjectBox, error) {
+	manager.mutex.Lock()
+
+	for {
+		if manager.closed {
+			manager.mutex.Unlock()
+			return nil, errors.New("store manager is closed")
+		}
+
+		if element, found := manager.stores[key]; found {
+			var store = element.Value.(*managedStore)
+			store.refs++
+			store.lastUsed = time.Now()
+			manager.lru.MoveToFront(element)
+			manager.mutex.Unlock()
+
+			<-store.opened
+			if store.err != nil {
+				return nil, store.err
+			}
+			return store.ob, nil
+		}
+
+		// the store may still be closing, e.g. after being idle; wait for it to finish and check again
+		var closing, found = manager.closing[key]
+		if !found {
+			break
+		}
+		manager.mutex.Unlock()
+		<-closing
+		manager.mutex.Lock()
+	}
+
+	// make room for the new store
+	var evicted *managedStore
+	if manager.maxOpenStores > 0 && manager.lru.Len() >= manager.maxOpenStores {
+		if evicted = manager.leastRecentlyUsed(); evicted == nil {
+			manager.mutex.Unlock()
+			return nil, fmt.Errorf("can't open store %q: all %d open stores are in use", key, manager.maxOpenStores)
+		}
+	}
+
+	// add a placeholder so that concurrent calls for the same key wait for this one to open the store
+	var store = &managedStore{key: key, opened: make(chan struct{}), refs: 1, lastUsed: time.Now()}
+	var element = manager.lru.PushFront(store)
+	manager.stores[key] = element
+	manager.pending.Add(1)
+	manager.mutex.Unlock()
+
+	defer manager.pending.Done()
+
+	if evicted != nil {
+		manager.closeStore(evicted)
+	}
+
+	ob, err := manager.open(key)
+
+	manager.mutex.Lock()
+	if err == nil && manager.closed {
+		// the manager was closed while opening the store and the placeholder is already gone
+		ob.Close()
+		ob, err = nil, errors.New("store manager is closed")
+	} else if err != nil && manager.stores[key] == element {
+		manager.lru.Remove(element)
+		delete(manager.stores, key)
+	}
+	store.ob, store.err = ob, err
+	close(store.opened)
+	manager.mutex.Unlock()
+
+	return ob, err
+}
+
+// Release marks the store with the given key as no longer used by the caller of the matching Acquire()
+func (manager *StoreManager) Release(key string) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if element, found := manager.stores[key]; found {
+		var store = element.Value.(*managedStore)
+		if store.refs > 0 {
+			store.refs--
+		}
+		store.lastUsed = time.Now()
+	}
+}
+
+// Run acquires the store with the given key, calls fn and releases the store afterwards
+func (manager *StoreManager) Run(key string, fn func(ob *ObjectBox) error) error {
+	ob, err := manager.Acquire(key)
+	if err != nil {
+		return err
+	}
+	defer manager.Release(key)
+	return fn(ob)
+}
+
+// OpenStores returns the number of currently open stores, including the ones being opened
+func (manager *StoreManager) OpenStores() int {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return manager.lru.Len()
+}
+
+// Close closes all stores, including those in use, and stops the manager; it's safe to call it multiple times.
+// Stores that are just being opened are closed by the Acquire() call opening them.
+func (manager *StoreManager) Close() {
+	var stores []*managedStore
+
+	manager.mutex.Lock()
+	if !manager.closed {
+		manager.closed = true
+		close(manager.stop)
+		for element := manager.lru.Front(); element != nil; element = element.Next() {
+			stores = append(stores, element.Value.(*managedStore))
+		}
+		manager.stores = make(map[string]*list.Element)
+		manager.lru.Init()
+	}
+	manager.mutex.Unlock()
+
+	for _, store := range stores {
+		<-store.opened
+		if store.ob != nil {
+			store.ob.Close()
+		}
+	}
+
+	<-manager.stopped
+	manager.pending.Wait()
+}
+
+// leastRecentlyUsed removes the least recently used store not in use from the manager and returns it, or nil if
+// there's no such store. The caller must close the returned store using closeStore() after unlocking the mutex.
+// Must be called with the mutex locked.
+func (manager *StoreManager) leastRecentlyUsed() *managedStore {
+	for element := manager.lru.Back(); element != nil; element = element.Prev() {
+		if element.Value.(*managedStore).refs == 0 {
+			return manager.remove(element)
+		}
+	}
+	return nil
+}
+
+// remove removes the store from the manager and marks it as closing. Must be called with the mutex locked.
+// Only stores not in use may be removed, thus they're already open.
+func (manager *StoreManager) remove(element *list.Element) *managedStore {
+	var store = element.Value.(*managedStore)
+	manager.lru.Remove(element)
+	delete(manager.stores, store.key)
+	manager.closing[store.key] = make(chan struct{})
+	return store
+}
+
+// closeStore closes a store previously removed by remove(). Must be called with the mutex unlocked.
+func (manager *StoreManager) closeStore(store *managedStore) {
+	store.ob.Close()
+
+	manager.mutex.Lock()
+	close(manager.closing[store.key])
+	delete(manager.closing, store.key)
+	manager.mutex.Unlock()
+}
+
+func (manager *StoreManager) closeIdleLoop() {
+	defer close(manager.stopped)
+
+	// check twice per timeout so that stores are closed at most idleTimeout * 1.5 after their last use
+	var interval = manager.idleTimeout / 2
+	if interval <= 0 {
+		interval = manager.idleTimeout // a ticker requires a positive interval, i.e. for a 1ns timeout
+	}
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-manager.stop:
+			return
+		case <-ticker.C:
+			manager.closeIdle()
+		}
+	}
+}
+
+// closeIdle closes the stores not in use that haven't been used for at least idleTimeout
+func (manager *StoreManager) closeIdle() {
+	var stores []*managedStore
+
+	manager.mutex.Lock()
+	var threshold = time.Now().Add(-manager.idleTimeout)
+	for element := manager.lru.Back(); element != nil; {
+		var prev = element.Prev()
+		var store = element.Value.(*managedStore)
+		if store.refs == 0 && store.lastUsed.Before(threshold) {
+			stores = append(stores, manager.remove(element))
+		}
+		element = prev
+	}
+	manager.mutex.Unlock()
+
+	for _, store := range stores {
+		manager.closeStore(store)
+	}
+}
@@ -261,7 +261,28 @@ type syncLoginFailureListener func(code SyncLoginFailure)
 type syncCompletionListener func()
 type syncTimeListener func(time.Time)
 
-type SyncLoginFailure uint64 // TODO enumerate possible values
+// SyncLoginFailure is the reason code passed to the login failure listener
+type SyncLoginFailure uint64
+
+const (
+	// SyncLoginFailureRequestRejected means the server rejected the request
+	SyncLoginFailureRequestRejected SyncLoginFailure = C.OBXSyncCode_REQ_REJECTED
+
+	// SyncLoginFailureCredentialsRejected means the server didn't accept the credentials
+	SyncLoginFailureCredentialsRejected SyncLoginFailure = C.OBXSyncCode_CREDENTIALS_REJECTED
+
+	// SyncLoginFailureUnknown means the login failed for an unspecified reason
+	SyncLoginFailureUnknown SyncLoginFailure = C.OBXSyncCode_UNKNOWN
+
+	// SyncLoginFailureAuthUnreachable means the server couldn't reach the authentication service
+	SyncLoginFailureAuthUnreachable SyncLoginFailure = C.OBXSyncCode_AUTH_UNREACHABLE
+
+	// SyncLoginFailureBadVersion means the client and the server protocol versions are incompatible
+	SyncLoginFailureBadVersion SyncLoginFailure = C.OBXSyncCode_BAD_VERSION
+
+	// SyncLoginFailureClientIdTaken means another client with the same ID is already connected
+	SyncLoginFailureClientIdTaken SyncLoginFailure = C.OBXSyncCode_CLIENT_ID_TAKEN
+)
 
 // SetConnectionListener sets or overrides a previously set listener for a "connection" event.
 func (client *SyncClient) SetConnectionListener(callback syncConnectionListener) error {
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+
+import (
+	"fmt"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+// GetChildren reads all objects that have the object with the given ID set as their parent, i.e. the objects
+// pointing to it using the given self-referencing to-one relation (e.g. Folder_.Parent).
+//
+// Returns a slice of objects that should be cast to the appropriate type.
+func (box *Box) GetChildren(relation *RelationToOne, id uint64) (slice interface{}, err error) {
+	if err := box.checkTreeRelation(relation); err != nil {
+		return nil, err
+	}
+
+	err = box.ObjectBox.RunInReadTx(func() error {
+		ids, err := box.childIds(relation, id)
+		if err == nil {
+			slice, err = box.getManyExistingOrEmpty(ids)
+		}
+		return err
+	})
+	return slice, err
+}
+
+// GetSubtree reads all descendants of the object with the given ID, following the given self-referencing to-one
+// relation in the reverse direction (from parents to children). The objects are returned in breadth-first order,
+// i.e. children first, then grandchildren, etc. The given object itself is not included.
+// Use maxDepth to limit the number of levels read (e.g. 1 is the same as GetChildren()), or 0 to read all levels.
+//
+// All data is read in a single transaction. Returns an error if a cycle is detected.
+func (box *Box) GetSubtree(relation *RelationToOne, id uint64, maxDepth int) (slice interface{}, err error) {
+	if err := box.checkTreeRelation(relation); err != nil {
+		return nil, err
+	}
+
+	err = box.ObjectBox.RunInReadTx(func() error {
+		var ids []uint64
+		var visited = map[uint64]bool{id: true}
+		var level = []uint64{id}
+		for depth := 1; len(level) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+			var nextLevel []uint64
+			for _, parentId := range level {
+				childIds, err := box.childIds(relation, parentId)
+				if err != nil {
+					return err
+				}
+				for _, childId := range childIds {
+					if visited[childId] {
+						return fmt.Errorf("cycle detected: object %d is referenced repeatedly in the subtree of %d",
+							childId, id)
+					}
+					visited[childId] = true
+				}
+				nextLevel = append(nextLevel, childIds...)
+			}
+			ids = append(ids, nextLevel...)
+			level = nextLevel
+		}
+
+		var err error
+		slice, err = box.getManyExistingOrEmpty(ids)
+		return err
+	})
+	return slice, err
+}
+
+// GetAncestors reads the parent of the object with the given ID, its parent, and so on up to the root object,
+// i.e. the first one without a parent. The objects are returned in this order, the given object is not included.
+//
+// All data is read in a single transaction. Returns an error if a cycle is detected.
+func (box *Box) GetAncestors(relation *RelationToOne, id uint64) (slice interface{}, err error) {
+	if err := box.checkTreeRelation(relation); err != nil {
+		return nil, err
+	}
+
+	var slot = flatbuffers.VOffsetT(4 + 2*(relation.Property.Id-1))
+	err = box.ObjectBox.RunInReadTx(func() error {
+		var ids []uint64
+		var visited = map[uint64]bool{}
+		for currentId := id; currentId != 0; {
+			if visited[currentId] {
+				return fmt.Errorf("cycle detected: object %d is its own ancestor", currentId)
+			}
+			visited[currentId] = true
+
+			var parentId uint64
+			found, err := box.GetRaw(currentId, func(bytes []byte) error {
+				var table = &flatbuffers.Table{
+					Bytes: bytes,
+					Pos:   flatbuffers.GetUOffsetT(bytes),
+				}
+				parentId = fbutils.GetUint64Slot(table, slot)
+				return nil
+			})
+			if err != nil {
+				return err
+			} else if !found {
+				// the parent referenced by the previous object doesn't exist (anymore) so this is where the path ends
+				break
+			}
+
+			if currentId != id {
+				ids = append(ids, currentId)
+			}
+			currentId = parentId
+		}
+
+		var err error
+		slice, err = box.getManyExistingOrEmpty(ids)
+		return err
+	})
+	return slice, err
+}
+
+// checkTreeRelation verifies the given relation links objects of this box to each other
+func (box *Box) checkTreeRelation(relation *RelationToOne) error {
+	if relation == nil || relation.Property == nil || relation.Property.Entity == nil || relation.Target == nil {
+		return fmt.Errorf("relation is not defined")
+	}
+
+	if relation.Property.Entity.Id != box.entity.id || relation.Target.Id != box.entity.id {
+		return fmt.Errorf("relation property %d must link entity %s to itself", relation.Property.Id, box.entity.name)
+	}
+	return nil
+}
+
+// childIds returns IDs of objects pointing to the given parent ID using the given relation
+func (box *Box) childIds(relation *RelationToOne, parentId uint64) ([]uint64, error) {
+	return cGetIds(func() *C.OBX_id_array {
+		return C.obx_box_get_backlink_ids(box.cBox, C.obx_schema_id(relation.Property.Id), C.obx_id(parentId))
+	})
+}
+
+// getManyExistingOrEmpty is the same as GetManyExisting but returns an empty slice instead of calling C for no IDs
+func (box *Box) getManyExistingOrEmpty(ids []uint64) (interface{}, error) {
+	if len(ids) == 0 {
+		return box.entity.binding.MakeSlice(0), nil
+	}
+	return box.GetManyExisting(ids...)
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdint.h>
+
+// ID of the top-level write transaction running on the current OS thread, 0 if there's none
+static __thread uint64_t goWriteTxId = 0;
+
+static uint64_t goWriteTxIdSwap(uint64_t id) {
+	uint64_t previous = goWriteTxId;
+	goWriteTxId = id;
+	return previous;
+}
+
+static uint64_t goWriteTxIdGet() { return goWriteTxId; }
+*/
+import "C"
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// TxListener is notified after a write transaction started by RunInWriteTx() has finished, see RegisterTxListener().
+// Nested transactions aren't reported on their own, only the outcome of the top-level transaction.
+// Writes outside of an explicit transaction (e.g. a single Box.Put) and async puts aren't reported.
+type TxListener struct {
+	// OnCommit is called after a transaction was committed successfully
+	OnCommit func()
+
+	// OnRollback is called after a transaction was aborted, i.e. nothing was written; err is the reason
+	OnRollback func(err error)
+}
+
+// txCallbacks are registered for a single transaction by AfterCommit() and AfterRollback()
+type txCallbacks struct {
+	commit   []func()
+	rollback []func(err error)
+}
+
+// lastWriteTxId is the last ID assigned to a write transaction. It's shared by all stores, because the ID is kept in
+// a single thread-local variable (goWriteTxId), which must identify the transaction among transactions of all stores.
+var lastWriteTxId uint64
+
+type txListeners struct {
+	mutex          sync.Mutex
+	lastListenerId uint64
+	listeners      map[uint64]TxListener
+	running        map[uint64]*txCallbacks // transactions in progress by their ID
+	listenerIds    []uint64                // in the order of registration
+}
+
+// RegisterTxListener adds a listener notified after each write transaction commits or rolls back, e.g. to invalidate
+// caches or to send outbox messages only for data that was actually stored. Listeners are called synchronously by the
+// goroutine which ran the transaction, after the transaction was closed; they may start new transactions.
+// Call the returned function to remove the listener.
+func (ob *ObjectBox) RegisterTxListener(listener TxListener) (unregister func()) {
+	var txl = &ob.txListeners
+	txl.mutex.Lock()
+	defer txl.mutex.Unlock()
+
+	if txl.listeners == nil {
+		txl.listeners = make(map[uint64]TxListener)
+	}
+	txl.lastListenerId++
+	var id = txl.lastListenerId
+	txl.listeners[id] = listener
+	txl.listenerIds = append(txl.listenerIds, id)
+
+	return func() {
+		txl.mutex.Lock()
+		defer txl.mutex.Unlock()
+
+		delete(txl.listeners, id)
+		for i, listenerId := range txl.listenerIds {
+			if listenerId == id {
+				txl.listenerIds = append(txl.listenerIds[:i], txl.listenerIds[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// AfterCommit registers a function to be called once the current write transaction has been committed.
+// It must be called inside RunInWriteTx() and the function isn't called if the transaction is rolled back.
+func (ob *ObjectBox) AfterCommit(fn func()) error {
+	return ob.currentTx(func(callbacks *txCallbacks) {
+		callbacks.commit = append(callbacks.commit, fn)
+	})
+}
+
+// AfterRollback registers a function to be called if the current write transaction is rolled back.
+// It must be called inside RunInWriteTx(), e.g. to clean up files written for objects that aren't stored in the end.
+func (ob *ObjectBox) AfterRollback(fn func(err error)) error {
+	return ob.currentTx(func(callbacks *txCallbacks) {
+		callbacks.rollback = append(callbacks.rollback, fn)
+	})
+}
+
+func (ob *ObjectBox) currentTx(fn func(callbacks *txCallbacks)) error {
+	var txl = &ob.txListeners
+	txl.mutex.Lock()
+	defer txl.mutex.Unlock()
+
+	// the transaction ID can be read safely: RunInWriteTx locks the goroutine to the OS thread
+	var callbacks = txl.running[uint64(C.goWriteTxIdGet())]
+	if callbacks == nil {
+		return errors.New("not inside a write transaction of this store")
+	}
+	fn(callbacks)
+	return nil
+}
+
+// beginWriteTx is called by the transaction on a locked OS thread. Returns the ID of the transaction if it's a
+// top-level one and the previous value of the thread-local transaction ID (a transaction of another store) to restore.
+// Returns a zero ID for nested transactions.
+func (txl *txListeners) beginWriteTx() (id uint64, previous C.uint64_t) {
+	txl.mutex.Lock()
+	defer txl.mutex.Unlock()
+
+	if txl.running[uint64(C.goWriteTxIdGet())] != nil {
+		return 0, 0 // nested transaction
+	}
+
+	if txl.running == nil {
+		txl.running = make(map[uint64]*txCallbacks)
+	}
+	id = atomic.AddUint64(&lastWriteTxId, 1)
+	txl.running[id] = &txCallbacks{}
+	return id, C.goWriteTxIdSwap(C.uint64_t(id))
+}
+
+// endWriteTx is called while the OS thread is still locked, before the transaction is closed
+func (txl *txListeners) endWriteTx(previous C.uint64_t) {
+	C.goWriteTxIdSwap(previous)
+}
+
+// notifyWriteTx calls the listeners and callbacks of the finished transaction; err is the transaction result
+func (txl *txListeners) notifyWriteTx(id uint64, err error) {
+	txl.mutex.Lock()
+	var callbacks = txl.running[id]
+	delete(txl.running, id)
+	var listeners = make([]TxListener, 0, len(txl.listenerIds))
+	for _, listenerId := range txl.listenerIds {
+		listeners = append(listeners, txl.listeners[listenerId])
+	}
+	txl.mutex.Unlock()
+
+	if err == nil {
+		for _, fn := range callbacks.commit {
+			fn()
+		}
+		for _, listener := range listeners {
+			if listener.OnCommit != nil {
+				listener.OnCommit()
+			}
+		}
+	} else {
+		for _, fn := range callbacks.rollback {
+			fn(err)
+		}
+		for _, listener := range listeners {
+			if listener.OnRollback != nil {
+				listener.OnRollback(err)
+			}
+		}
+	}
+}
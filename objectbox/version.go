@@ -19,6 +19,11 @@ package objectbox
 /*
 #include <stdlib.h>
 #include "objectbox.h"
+
+// the binding uses the C-API as declared in the 0.x headers, starting with VersionLibMin()
+#if OBX_VERSION_MAJOR != 0 || OBX_VERSION_MINOR < 15
+#error "objectbox.h is incompatible with this version of ObjectBox Go, a 0.x version of at least 0.15.0 is required"
+#endif
 */
 import "C"
 import "fmt"
@@ -33,7 +38,13 @@ type Version struct {
 }
 
 func (v Version) LessThan(other Version) bool {
-	return v.Major < other.Major || v.Minor < other.Minor || v.Patch < other.Patch
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
 }
 
 func (v Version) GreaterThanOrEqualTo(other Version) bool {
@@ -82,6 +93,63 @@ func VersionLibMinRecommended() Version {
 	return Version{0, 15, 1, ""}
 }
 
+// IncompatibleCoreVersionError is returned if the loaded ObjectBox native library can't be used with this version of
+// ObjectBox Go, e.g. by Builder.BuildOrError() after the shared library was upgraded to an incompatible version.
+// Compare it using errors.Is(err, objectbox.ErrIncompatibleCoreVersion) or errors.As() to access the details,
+// or, before Go 1.13, using a type assertion.
+type IncompatibleCoreVersionError struct {
+	// Found is the version of the loaded library, see VersionLib()
+	Found Version
+
+	// Min is the lowest compatible version, see VersionLibMin()
+	Min Version
+
+	// Static is the version of the C-API header this binding was compiled against; a library with a different major
+	// version isn't compatible, see VersionLibStatic()
+	Static Version
+}
+
+// Error returns the error message
+func (err *IncompatibleCoreVersionError) Error() string {
+	return fmt.Sprintf("the loaded ObjectBox C library version %v is incompatible with ObjectBox Go %v, "+
+		"expected version %d.x of at least %v (compiled against %v); "+
+		"see https://github.com/objectbox/objectbox-go on how to install a matching version",
+		err.Found, VersionGo(), err.Static.Major, err.Min, err.Static)
+}
+
+// Is reports whether the target is an IncompatibleCoreVersionError; used by errors.Is()
+func (err *IncompatibleCoreVersionError) Is(target error) bool {
+	_, ok := target.(*IncompatibleCoreVersionError)
+	return ok
+}
+
+// ErrIncompatibleCoreVersion - the loaded native library can't be used, see IncompatibleCoreVersionError
+var ErrIncompatibleCoreVersion error = &IncompatibleCoreVersionError{}
+
+// CheckLibVersion returns an *IncompatibleCoreVersionError if the loaded ObjectBox native library is older than
+// VersionLibMin() or has a different major version than the one this binding was compiled against.
+// NewBuilder() checks this as well, so BuildOrError() fails instead of the library crashing later.
+func CheckLibVersion() error {
+	return checkLibVersion(VersionLib())
+}
+
+func checkLibVersion(found Version) error {
+	var static = VersionLibStatic()
+	if found.LessThan(VersionLibMin()) || found.Major != static.Major {
+		return &IncompatibleCoreVersionError{Found: found, Min: VersionLibMin(), Static: static}
+	}
+	return nil
+}
+
+// versionLibInMemory is the first version of the ObjectBox C library supporting "memory:" directories
+var versionLibInMemory = Version{0, 18, 0, ""}
+
+// InMemoryIsAvailable returns true if the loaded ObjectBox native library supports in-memory databases.
+// See Builder.InMemory().
+func InMemoryIsAvailable() bool {
+	return VersionLib().GreaterThanOrEqualTo(versionLibInMemory)
+}
+
 // VersionInfo returns a printable version string
 func VersionInfo() string {
 	return "ObjectBox Go version " + VersionGo().String() + " using dynamic library version " + VersionLib().String()
@@ -0,0 +1,205 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdint.h>
+
+// ID of the write queue whose turn is held by the current OS thread, 0 if there's none
+static __thread uint64_t goWriteQueueId = 0;
+
+static uint64_t goWriteQueueIdSwap(uint64_t id) {
+	uint64_t previous = goWriteQueueId;
+	goWriteQueueId = id;
+	return previous;
+}
+
+static uint64_t goWriteQueueIdGet() { return goWriteQueueId; }
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrWriteQueueFull is returned by write operations if the maximum number of writers are already waiting,
+// see Builder.WriteQueue()
+var ErrWriteQueueFull = errors.New("write queue is full")
+
+// ErrWriteQueueTimeout is returned by write operations which couldn't start in time, see Builder.WriteQueue()
+var ErrWriteQueueTimeout = errors.New("timed out waiting for the write queue")
+
+// WriteQueueStats provides information about the write queue, see ObjectBox.WriteQueueStats()
+type WriteQueueStats struct {
+	// Waiting is the number of writers currently waiting for their turn
+	Waiting int
+
+	// MaxWaiting is the highest number of writers waiting at the same time so far
+	MaxWaiting int
+
+	// Acquired is the number of writes which got their turn, with or without waiting
+	Acquired uint64
+
+	// Rejected is the number of writes which failed with ErrWriteQueueFull
+	Rejected uint64
+
+	// TimedOut is the number of writes which failed with ErrWriteQueueTimeout
+	TimedOut uint64
+
+	// WaitTime is the total time the acquired writes have spent waiting for their turn
+	WaitTime time.Duration
+}
+
+// writeQueueOptions holds the options of Builder.WriteQueue()
+type writeQueueOptions struct {
+	maxWaiting int
+	timeout    time.Duration
+}
+
+// writeQueue lets writers (write transactions and writes outside of an explicit transaction) take turns in the order
+// they arrived, instead of all of them blocking inside the native library until they get the write transaction.
+type writeQueue struct {
+	options writeQueueOptions
+
+	// identifies the queue in the thread-local goWriteQueueId, unique in the process
+	id uint64
+
+	// only accessed by the writer holding the turn: the number of its (nested) writes and the thread-local queue ID
+	// to restore when the turn ends, i.e. of another store's queue whose turn is held by the same OS thread
+	depth      int
+	previousId C.uint64_t
+
+	// holds a single token while no writer has its turn; waiting receivers are served in FIFO order
+	turn chan struct{}
+
+	mutex sync.Mutex
+	stats WriteQueueStats
+}
+
+// lastWriteQueueId is the last ID assigned to a write queue
+var lastWriteQueueId uint64
+
+func newWriteQueue(options writeQueueOptions) *writeQueue {
+	var queue = &writeQueue{
+		options: options,
+		id:      atomic.AddUint64(&lastWriteQueueId, 1),
+		turn:    make(chan struct{}, 1),
+	}
+	queue.turn <- struct{}{}
+	return queue
+}
+
+// acquire waits for the turn of the current writer; it must be followed by release() if (and only if) it succeeds.
+// Writes nested in another write to the same store on the same OS thread (i.e. inside a write transaction) already
+// have the turn; writes to another store nested in it wait for the turn of that store's queue.
+func (queue *writeQueue) acquire() error {
+	if queue == nil {
+		return nil
+	}
+
+	runtime.LockOSThread()
+	if uint64(C.goWriteQueueIdGet()) == queue.id {
+		queue.depth++
+		return nil
+	}
+
+	if err := queue.wait(); err != nil {
+		runtime.UnlockOSThread()
+		return err
+	}
+	queue.previousId = C.goWriteQueueIdSwap(C.uint64_t(queue.id))
+	queue.depth = 1
+	return nil
+}
+
+// release ends the turn taken by acquire()
+func (queue *writeQueue) release() {
+	if queue == nil {
+		return
+	}
+
+	if queue.depth--; queue.depth == 0 {
+		C.goWriteQueueIdSwap(queue.previousId)
+		queue.turn <- struct{}{}
+	}
+	runtime.UnlockOSThread()
+}
+
+func (queue *writeQueue) wait() error {
+	// fast path - nobody is writing; if others are waiting, the turn is handed to them directly instead
+	select {
+	case <-queue.turn:
+		queue.mutex.Lock()
+		queue.stats.Acquired++
+		queue.mutex.Unlock()
+		return nil
+	default:
+	}
+
+	queue.mutex.Lock()
+	if queue.options.maxWaiting > 0 && queue.stats.Waiting >= queue.options.maxWaiting {
+		queue.stats.Rejected++
+		queue.mutex.Unlock()
+		return ErrWriteQueueFull
+	}
+	queue.stats.Waiting++
+	if queue.stats.Waiting > queue.stats.MaxWaiting {
+		queue.stats.MaxWaiting = queue.stats.Waiting
+	}
+	queue.mutex.Unlock()
+
+	var start = time.Now()
+	var timeout <-chan time.Time
+	if queue.options.timeout > 0 {
+		var timer = time.NewTimer(queue.options.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-queue.turn:
+		queue.mutex.Lock()
+		defer queue.mutex.Unlock()
+		queue.stats.Waiting--
+		queue.stats.Acquired++
+		queue.stats.WaitTime += time.Since(start)
+		return nil
+
+	case <-timeout:
+		queue.mutex.Lock()
+		defer queue.mutex.Unlock()
+		queue.stats.Waiting--
+		queue.stats.TimedOut++
+		return ErrWriteQueueTimeout
+	}
+}
+
+// WriteQueueStats returns the current state of the write queue configured by Builder.WriteQueue(),
+// or zero values if the queue isn't enabled
+func (ob *ObjectBox) WriteQueueStats() WriteQueueStats {
+	if ob.writeQueue == nil {
+		return WriteQueueStats{}
+	}
+
+	ob.writeQueue.mutex.Lock()
+	defer ob.writeQueue.mutex.Unlock()
+	return ob.writeQueue.stats
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestAdmin(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	if !objectbox.AdminIsAvailable() {
+		_, err := objectbox.NewAdmin(env.ObjectBox, objectbox.AdminOptions{})
+		assert.Err(t, err)
+		t.Skip("Admin is not available in the loaded library")
+	}
+
+	admin, err := objectbox.NewAdmin(env.ObjectBox, objectbox.AdminOptions{BindUri: "http://127.0.0.1:0", Unsecured: true})
+	assert.NoErr(t, err)
+	defer admin.Close()
+
+	var port = admin.Port()
+	assert.True(t, port > 0)
+
+	response, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/", port))
+	assert.NoErr(t, err)
+	assert.NoErr(t, response.Body.Close())
+	assert.Eq(t, http.StatusOK, response.StatusCode)
+
+	assert.NoErr(t, admin.Close())
+	assert.NoErr(t, admin.Close()) // double close
+}
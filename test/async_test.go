@@ -126,3 +126,28 @@ func testAsync(t *testing.T, asyncF func(box *model.TestEntityInlineBox) *model.
 	assert.NoErr(t, async.RemoveId(object.Id))
 	waitAndCount(1)
 }
+
+func TestAsyncReadYourWrites(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = model.BoxForTestEntityInline(env.ObjectBox)
+	box.SetAsyncReadYourWrites(true)
+
+	var query = box.Query()
+	defer query.Close()
+
+	for i := 1; i <= 100; i++ {
+		_, err := box.Async().Put(&model.TestEntityInline{BaseWithValue: &model.BaseWithValue{}})
+		assert.NoErr(t, err)
+	}
+
+	count, err := query.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(100), count)
+
+	// no pending operations anymore, the query doesn't wait
+	objects, err := query.Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 100, len(objects))
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestBackup(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	_, err := box.PutMany([]*iot.Event{{Device: "Pi 3B"}, {Device: "Pi Zero"}})
+	assert.NoErr(t, err)
+
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var backupFile = filepath.Join(dir, "backup.mdb")
+	assert.NoErr(t, env.ObjectBox.Backup(backupFile))
+
+	// changes after the backup are not part of it
+	_, err = box.Put(&iot.Event{Device: "Pi 4"})
+	assert.NoErr(t, err)
+
+	var restoredDir = filepath.Join(dir, "restored")
+	var openRestored = func() *objectbox.ObjectBox {
+		ob, err := objectbox.NewBuilder().Directory(restoredDir).FromBackup(backupFile).
+			Model(iot.ObjectBoxModel()).BuildOrError()
+		assert.NoErr(t, err)
+		return ob
+	}
+
+	restored := openRestored()
+	events, err := iot.BoxForEvent(restored).GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(events))
+	assert.Eq(t, "Pi Zero", events[1].Device)
+
+	// an existing database is not overwritten by the backup
+	_, err = iot.BoxForEvent(restored).Put(&iot.Event{Device: "Pi 4"})
+	assert.NoErr(t, err)
+	restored.Close()
+
+	restored = openRestored()
+	defer restored.Close()
+	count, err := iot.BoxForEvent(restored).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+
+	// a missing backup file is reported
+	_, err = objectbox.NewBuilder().Directory(filepath.Join(dir, "missing")).FromBackup(filepath.Join(dir, "none.mdb")).
+		Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.Err(t, err)
+}
+
+func TestBackupSchemaVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var runs int
+	var builder = func(directory string) *objectbox.Builder {
+		return objectbox.NewBuilder().Directory(directory).Model(iot.ObjectBoxModel()).
+			SchemaVersionProperty(iot.SchemaVersion_.Version).
+			MigrateTo(1, func(tx *objectbox.MigrationTx) error {
+				runs++
+				return nil
+			})
+	}
+
+	ob, err := builder(filepath.Join(dir, "source")).BuildOrError()
+	assert.NoErr(t, err)
+	var backupFile = filepath.Join(dir, "backup.mdb")
+	assert.NoErr(t, ob.Backup(backupFile))
+	ob.Close()
+	assert.Eq(t, 1, runs)
+
+	// the migration step already applied before the backup doesn't run again on the restored store
+	restored, err := builder(filepath.Join(dir, "restored")).FromBackup(backupFile).BuildOrError()
+	assert.NoErr(t, err)
+	defer restored.Close()
+	assert.Eq(t, 1, restored.SchemaVersion())
+	assert.Eq(t, 1, runs)
+}
+
+func TestCompact(t *testing.T) {
+	env := model.NewTestEnv(t).SetOptions(model.TestEnvOptions{PopulateRelations: true})
+	defer env.Close()
+	env.Populate(10)
+
+	removed, err := env.Box.RemoveIds(2, 5, 6)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), removed)
+
+	expected, err := env.Box.GetAll()
+	assert.NoErr(t, err)
+
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var compactedDir = filepath.Join(dir, "compacted")
+	assert.NoErr(t, env.ObjectBox.Compact(compactedDir, model.ObjectBoxModel()))
+
+	// the target must not contain a database already
+	assert.Err(t, env.ObjectBox.Compact(compactedDir, model.ObjectBoxModel()))
+
+	compacted, err := objectbox.NewBuilder().Directory(compactedDir).Model(model.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer compacted.Close()
+
+	// objects keep their IDs and relations
+	var box = model.BoxForEntity(compacted)
+	actual, err := box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, expected, actual)
+
+	assert.NoErr(t, env.Box.FetchRelatedPtrSlice(expected...))
+	assert.NoErr(t, box.FetchRelatedPtrSlice(actual...))
+	assert.Eq(t, expected, actual)
+	assert.Eq(t, 1, len(actual[0].RelatedPtrSlice))
+
+	// new objects get IDs after the copied ones
+	id, err := box.Put(model.Entity47())
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(11), id)
+}
+
+func TestCompactBatches(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	// more objects than are copied in a single batch
+	env.Populate(2500)
+
+	expected, err := env.Box.GetAll()
+	assert.NoErr(t, err)
+
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var compactedDir = filepath.Join(dir, "compacted")
+	assert.NoErr(t, env.ObjectBox.Compact(compactedDir, model.ObjectBoxModel()))
+
+	compacted, err := objectbox.NewBuilder().Directory(compactedDir).Model(model.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer compacted.Close()
+
+	actual, err := model.BoxForEntity(compacted).GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, expected, actual)
+}
@@ -63,6 +63,11 @@ type benchmarkEnv struct {
 }
 
 func newBenchEnv(b *testing.B) *benchmarkEnv {
+	return newBenchEnvWithOptions(b, nil)
+}
+
+// newBenchEnvWithOptions creates an environment with the builder tuned by the given function
+func newBenchEnvWithOptions(b *testing.B, configure func(builder *objectbox.Builder)) *benchmarkEnv {
 	b.StopTimer()
 	b.SetBytes(1) // report speed in MB/s where one B is one object; overridden in bulk ops
 
@@ -71,8 +76,13 @@ func newBenchEnv(b *testing.B) *benchmarkEnv {
 		b:      b,
 	}
 
+	var builder = objectbox.NewBuilder().Directory(env.dbName).Model(perf.ObjectBoxModel())
+	if configure != nil {
+		configure(builder)
+	}
+
 	var err error
-	env.ob, err = objectbox.NewBuilder().Directory(env.dbName).Model(perf.ObjectBoxModel()).Build()
+	env.ob, err = builder.Build()
 	env.check(err)
 
 	env.box = perf.BoxForEntity(env.ob)
@@ -227,3 +237,69 @@ func BenchmarkSlowGet(b *testing.B) {
 		env.check(err)
 	}
 }
+
+// BenchmarkAsyncPut submits many individual puts to the async queue, executed in batched transactions.
+func BenchmarkAsyncPut(b *testing.B) {
+	var env = newBenchEnvWithOptions(b, func(builder *objectbox.Builder) {
+		builder.AsyncMaxTxOperations(10000)
+	})
+	defer env.close()
+
+	// prepare the data first
+	var inserts = prepareBenchData(b, b.N)
+
+	var async = env.box.Async()
+	for n := 0; n < b.N; n++ {
+		_, err := async.Put(inserts[n])
+		env.check(err)
+	}
+	env.check(async.AwaitSubmitted())
+}
+
+// BenchmarkQueryFind executes a query matching half of the stored objects
+func BenchmarkQueryFind(b *testing.B) {
+	var env = newBenchEnv(b)
+	defer env.close()
+	var inserts = prepareBenchData(b, bulkCount())
+
+	b.StopTimer()
+	_, err := env.box.PutMany(inserts)
+	env.check(err)
+	var query = env.box.Query(perf.Entity_.Int64.GreaterOrEqual(int64(bulkCount() / 2)))
+	defer query.Close()
+	b.StartTimer()
+
+	b.Run(fmt.Sprintf("count=%v", bulkCount()), func(b *testing.B) {
+		b.SetBytes(int64(bulkCount() / 2)) // report speed in MB/s where one B is one object
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_, err := query.Find()
+			env.check(err)
+		}
+	})
+}
+
+// BenchmarkPropertyQuerySum aggregates a property over all stored objects
+func BenchmarkPropertyQuerySum(b *testing.B) {
+	var env = newBenchEnv(b)
+	defer env.close()
+	var inserts = prepareBenchData(b, bulkCount())
+
+	b.StopTimer()
+	_, err := env.box.PutMany(inserts)
+	env.check(err)
+	var query = env.box.Query()
+	defer query.Close()
+	var propQuery = query.Property(perf.Entity_.Int64)
+	defer propQuery.Close()
+	b.StartTimer()
+
+	b.Run(fmt.Sprintf("count=%v", bulkCount()), func(b *testing.B) {
+		b.SetBytes(int64(bulkCount())) // report speed in MB/s where one B is one object
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_, err := propQuery.Sum()
+			env.check(err)
+		}
+	})
+}
@@ -17,6 +17,7 @@
 package objectbox_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/objectbox/objectbox-go/test/assert"
@@ -214,6 +215,97 @@ func TestBoxInsert(t *testing.T) {
 	assert.True(t, id == 0 && object.Id == 1)
 }
 
+func TestBoxIdsForPut(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	firstId, err := box.IdsForPut(3)
+	assert.NoErr(t, err)
+	assert.True(t, firstId > 0)
+
+	var events = []*iot.Event{{Id: firstId}, {Id: firstId + 1}, {Id: firstId + 2}}
+	ids, err := box.PutMany(events)
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{firstId, firstId + 1, firstId + 2}, ids)
+
+	// the reserved IDs aren't assigned again
+	id, err := box.Put(&iot.Event{})
+	assert.NoErr(t, err)
+	assert.Eq(t, firstId+3, id)
+
+	_, err = box.IdsForPut(-1)
+	assert.Err(t, err)
+}
+
+func TestBoxPutIfAbsent(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var object = model.Entity47()
+	id, inserted, err := env.Box.PutIfAbsent(object)
+	assert.NoErr(t, err)
+	assert.True(t, inserted)
+	assert.True(t, id == 1 && object.Id == 1)
+
+	// the stored object is left untouched
+	object.String = "changed"
+	id, inserted, err = env.Box.PutIfAbsent(object)
+	assert.NoErr(t, err)
+	assert.True(t, !inserted)
+	assert.Eq(t, uint64(1), id)
+
+	stored, err := env.Box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, model.Entity47().String, stored.String)
+
+	// an assigned ID that isn't stored yet is inserted
+	var stringIdBox = model.BoxForTestStringIdEntity(env.ObjectBox)
+	id, inserted, err = stringIdBox.PutIfAbsent(&model.TestStringIdEntity{Id: "10"})
+	assert.NoErr(t, err)
+	assert.True(t, inserted)
+	assert.Eq(t, uint64(10), id)
+}
+
+func TestBoxGetOrInsert(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var object = model.Entity47()
+	result, inserted, err := env.Box.GetOrInsert(object, model.Entity_.String)
+	assert.NoErr(t, err)
+	assert.True(t, inserted)
+	assert.True(t, result == object && object.Id == 1)
+
+	// an object with the same value is returned instead of inserting the new one
+	var duplicate = model.Entity47()
+	duplicate.Int32 = 1
+	result, inserted, err = env.Box.GetOrInsert(duplicate, model.Entity_.String)
+	assert.NoErr(t, err)
+	assert.True(t, !inserted)
+	assert.Eq(t, uint64(1), result.(*model.Entity).Id)
+	assert.Eq(t, uint64(0), duplicate.Id)
+
+	// other property types work as well
+	result, inserted, err = env.Box.GetOrInsert(duplicate, model.Entity_.Int64)
+	assert.NoErr(t, err)
+	assert.True(t, !inserted)
+	assert.Eq(t, uint64(1), result.(*model.Entity).Id)
+
+	duplicate.String = "different"
+	_, inserted, err = env.Box.GetOrInsert(duplicate, model.Entity_.String)
+	assert.NoErr(t, err)
+	assert.True(t, inserted)
+	assert.Eq(t, uint64(2), duplicate.Id)
+
+	count, err := env.Box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	_, _, err = env.Box.GetOrInsert(model.Entity47(), model.TestEntityRelated_.Name)
+	assert.Err(t, err)
+}
+
 func TestBoxUpdate(t *testing.T) {
 	var env = model.NewTestEnv(t)
 	defer env.Close()
@@ -241,6 +333,45 @@ func TestBoxUpdate(t *testing.T) {
 	assert.Eq(t, object, objectRead)
 }
 
+func TestBoxBulkCtx(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	events := []*iot.Event{{Device: "Pi 3B"}, {Device: "Pi Zero"}}
+
+	// a cancelled context rolls back the whole transaction
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ids, err := box.PutManyCtx(ctx, events)
+	assert.Eq(t, context.Canceled, err)
+	assert.Eq(t, 0, len(ids))
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), count)
+
+	_, err = box.GetAllCtx(ctx)
+	assert.Eq(t, context.Canceled, err)
+
+	_, err = box.Query().FindCtx(ctx)
+	assert.Eq(t, context.Canceled, err)
+
+	// an active context behaves just like the variants without it
+	ids, err = box.PutManyCtx(context.Background(), events)
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(ids))
+
+	all, err := box.GetAllCtx(context.Background())
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(all.([]*iot.Event)))
+
+	found, err := box.Query(iot.Event_.Device.Equals("Pi Zero", true)).FindCtx(context.Background())
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(found.([]*iot.Event)))
+	assert.Eq(t, ids[1], found.([]*iot.Event)[0].Id)
+}
+
 func TestBoxCount(t *testing.T) {
 	var env = model.NewTestEnv(t)
 	defer env.Close()
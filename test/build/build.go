@@ -30,3 +30,14 @@ func Package(path string) (stdOut []byte, stdErr []byte, err error) {
 	}
 	return
 }
+
+// Generate runs `go generate` for the given package pattern (e.g. ./...) in the given directory
+func Generate(dir string, pattern string) (stdOut []byte, stdErr []byte, err error) {
+	var cmd = exec.Command("go", "generate", pattern)
+	cmd.Dir = dir
+	stdOut, err = cmd.Output()
+	if ee, ok := err.(*exec.ExitError); ok {
+		stdErr = ee.Stderr
+	}
+	return
+}
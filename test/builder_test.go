@@ -0,0 +1,162 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestBuilderReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	id, err := iot.BoxForEvent(ob).Put(&iot.Event{Device: "dev"})
+	assert.NoErr(t, err)
+	ob.Close()
+
+	ob, err = objectbox.NewBuilder().Directory(dir).ReadOnly(true).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	box := iot.BoxForEvent(ob)
+	event, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "dev", event.Device)
+
+	_, err = box.Put(&iot.Event{Device: "dev2"})
+	assert.Err(t, err)
+}
+
+func TestBuilderUsePreviousCommit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	_, err = iot.BoxForEvent(ob).Put(&iot.Event{Device: "first"})
+	assert.NoErr(t, err)
+	_, err = iot.BoxForEvent(ob).Put(&iot.Event{Device: "second"})
+	assert.NoErr(t, err)
+	ob.Close()
+
+	// the last transaction (putting "second") is ignored
+	ob, err = objectbox.NewBuilder().Directory(dir).ReadOnly(true).UsePreviousCommit(true).
+		Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	count, err := iot.BoxForEvent(ob).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+}
+
+func TestBuilderValidateOnOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	iot.PutEvents(ob, 100)
+	ob.Close()
+
+	ob, err = objectbox.NewBuilder().Directory(dir).ValidateOnOpen(0, true).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	count, err := iot.BoxForEvent(ob).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(100), count)
+}
+
+func TestBuilderUnicodeDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var dbDir = filepath.Join(dir, "databáze 数据库")
+	ob, err := objectbox.NewBuilder().Directory(dbDir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	_, err = iot.BoxForEvent(ob).Put(&iot.Event{Device: "Pi 4"})
+	assert.NoErr(t, err)
+
+	// the files are created in the given directory, i.e. the name isn't mangled
+	_, err = os.Stat(filepath.Join(dbDir, "data.mdb"))
+	assert.NoErr(t, err)
+}
+
+func TestBuilderFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix file modes are not supported on Windows")
+	}
+
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).FileMode(0600).MaxReaders(10).MaxSizeInKb(10 * 1024).
+		Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	info, err := os.Stat(filepath.Join(dir, "data.mdb"))
+	assert.NoErr(t, err)
+	assert.Eq(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestBuilderEncryptionKeyUnsupported(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).EncryptionKey(make([]byte, 32)).
+		Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.Err(t, err)
+	assert.True(t, ob == nil)
+}
+
+func TestBuilderNoReaderThreadLocals(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).NoReaderThreadLocals(true).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	box := iot.BoxForEvent(ob)
+	id, err := box.Put(&iot.Event{Device: "dev"})
+	assert.NoErr(t, err)
+
+	event, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "dev", event.Device)
+}
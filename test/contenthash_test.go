@@ -0,0 +1,75 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestContentHash(t *testing.T) {
+	var hash = func(values ...interface{}) string {
+		result, err := objectbox.ContentHash(values...)
+		assert.NoErr(t, err)
+		assert.Eq(t, 64, len(result))
+		return result
+	}
+
+	var text = "text"
+	var date = time.Unix(1600000000, 0)
+	assert.Eq(t, hash("a", int64(1), []byte{1}, date), hash("a", int64(1), []byte{1}, date))
+	assert.Eq(t, hash(text), hash(&text))
+	assert.True(t, hash("ab", "c") != hash("a", "bc"))
+	assert.True(t, hash([]string{"ab", "c"}) != hash([]string{"a", "bc"}))
+	assert.True(t, hash(int64(1)) != hash(uint64(1)))
+	assert.True(t, hash("") != hash(nil))
+	assert.True(t, hash(1.5, true) != hash(1.5, false))
+
+	_, err := objectbox.ContentHash(map[string]int{})
+	assert.Err(t, err)
+	_, err = objectbox.ContentHash([]int{1})
+	assert.Err(t, err)
+}
+
+func TestFindByContentHash(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var box = iot.BoxForEvent(env.ObjectBox)
+
+	// the unique Uid property serves as the content hash
+	var event = &iot.Event{Device: "sensor", Date: 1000}
+	var err error
+	event.Uid, err = objectbox.ContentHash(event.Device, event.Date)
+	assert.NoErr(t, err)
+	_, err = box.Put(event)
+	assert.NoErr(t, err)
+
+	found, err := box.FindByContentHash(iot.Event_.Uid, event.Uid)
+	assert.NoErr(t, err)
+	assert.Eq(t, event, found)
+
+	other, err := objectbox.ContentHash("sensor", int64(1001))
+	assert.NoErr(t, err)
+	found, err = box.FindByContentHash(iot.Event_.Uid, other)
+	assert.NoErr(t, err)
+	assert.True(t, found == nil)
+}
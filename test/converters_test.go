@@ -161,3 +161,26 @@ func TestTimeBinaryConverter(t *testing.T) {
 		assert.Eq(t, date, value)
 	}
 }
+
+func TestMapJsonConverter(t *testing.T) {
+	var value = map[string]interface{}{"name": "test", "tags": []interface{}{"a", "b"}, "size": float64(10)}
+
+	dbValue, err := objectbox.MapJsonConvertToDatabaseValue(value)
+	assert.NoErr(t, err)
+	assert.Eq(t, `{"name":"test","size":10,"tags":["a","b"]}`, dbValue)
+
+	read, err := objectbox.MapJsonConvertToEntityProperty(dbValue)
+	assert.NoErr(t, err)
+	assert.Eq(t, value, read)
+
+	dbValue, err = objectbox.MapJsonConvertToDatabaseValue(nil)
+	assert.NoErr(t, err)
+	assert.Eq(t, "", dbValue)
+
+	read, err = objectbox.MapJsonConvertToEntityProperty("")
+	assert.NoErr(t, err)
+	assert.True(t, read == nil)
+
+	_, err = objectbox.MapJsonConvertToEntityProperty("{")
+	assert.Err(t, err)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestQueryExportCSV(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	_, err := box.PutMany([]*iot.Event{
+		{Device: "Pi 3B", Date: 1, Picture: []byte{1, 2}},
+		{Device: "Pi; Zero", Date: -2},
+	})
+	assert.NoErr(t, err)
+
+	var buffer bytes.Buffer
+	var query = box.Query()
+	assert.NoErr(t, query.ExportCSV(&buffer, objectbox.CSVOptions{}, iot.Event_.Id, iot.Event_.Device, iot.Event_.Date))
+	assert.Eq(t, "1,Pi 3B,1\n2,Pi; Zero,-2\n", buffer.String())
+
+	buffer.Reset()
+	assert.NoErr(t, query.ExportCSV(&buffer, objectbox.CSVOptions{Delimiter: ';', Header: true},
+		iot.Event_.Device, iot.Event_.Picture))
+	assert.Eq(t, "Device;Picture\nPi 3B;AQI=\n\"Pi; Zero\";\n", buffer.String())
+
+	// invalid arguments
+	assert.Err(t, query.ExportCSV(&buffer, objectbox.CSVOptions{}))
+	assert.Err(t, query.ExportCSV(&buffer, objectbox.CSVOptions{}, iot.Reading_.ValueName))
+}
+
+func TestQueryExportCSVTypes(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	env.PutEntity(&model.Entity{Int8: -8, Int16: -16, Int32: -32, Uint8: 200, Uint32: 4000000000, Bool: true,
+		Float32: 1.5, StringVector: []string{"a"}})
+
+	var query = env.Box.Query()
+	defer query.Close()
+
+	var buffer bytes.Buffer
+	assert.NoErr(t, query.ExportCSV(&buffer, objectbox.CSVOptions{}, model.Entity_.Int8, model.Entity_.Int16,
+		model.Entity_.Int32, model.Entity_.Uint8, model.Entity_.Uint32, model.Entity_.Bool, model.Entity_.Float32))
+	assert.Eq(t, "-8,-16,-32,200,4000000000,true,1.5\n", buffer.String())
+
+	// unsupported types are rejected before anything is written
+	buffer.Reset()
+	assert.Err(t, query.ExportCSV(&buffer, objectbox.CSVOptions{Header: true}, model.Entity_.Int8,
+		model.Entity_.StringVector))
+	assert.Eq(t, 0, buffer.Len())
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestDumpEntity(t *testing.T) {
+	source := iot.NewTestEnv()
+	defer source.Close()
+
+	var events = iot.PutEvents(source.ObjectBox, 10)
+	assert.NoErr(t, iot.BoxForEvent(source.ObjectBox).Remove(events[0]))
+
+	var buffer bytes.Buffer
+	assert.NoErr(t, source.ObjectBox.DumpEntity(iot.EventBinding.Id, &buffer))
+	var dump = buffer.Bytes()
+
+	target := iot.NewTestEnv()
+	defer target.Close()
+
+	count, err := target.ObjectBox.LoadEntity(bytes.NewReader(dump))
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(9), count)
+
+	// objects keep their IDs and contents
+	loaded, err := iot.BoxForEvent(target.ObjectBox).GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, events[1:], loaded)
+
+	// new objects get IDs after the loaded ones
+	id, err := iot.BoxForEvent(target.ObjectBox).Put(&iot.Event{})
+	assert.NoErr(t, err)
+	assert.Eq(t, events[9].Id+1, id)
+
+	// loading again overwrites the objects
+	count, err = target.ObjectBox.LoadEntity(bytes.NewReader(dump))
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(9), count)
+
+	// truncated or invalid data is rejected, without loading anything
+	_, err = target.ObjectBox.LoadEntity(bytes.NewReader(dump[:len(dump)-1]))
+	assert.Err(t, err)
+	_, err = target.ObjectBox.LoadEntity(bytes.NewReader([]byte("invalid")))
+	assert.Err(t, err)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+)
+
+func dynamicTestModel() *objectbox.Model {
+	var model = objectbox.NewModel()
+	model.GeneratorVersion(6)
+	model.RegisterDynamicEntity(objectbox.DynamicEntity{
+		Name:          "PluginRecord",
+		Id:            1,
+		Uid:           7370421812296405001,
+		IdPropertyId:  1,
+		IdPropertyUid: 7370421812296405002,
+		Properties: []objectbox.DynamicProperty{
+			{Name: "Name", Type: objectbox.PropertyTypeString, Id: 2, Uid: 7370421812296405003,
+				IndexId: 1, IndexUid: 7370421812296405004},
+			{Name: "Size", Type: objectbox.PropertyTypeInt, Id: 3, Uid: 7370421812296405005},
+			{Name: "Score", Type: objectbox.PropertyTypeDouble, Id: 4, Uid: 7370421812296405006},
+			{Name: "Created", Type: objectbox.PropertyTypeDate, Id: 5, Uid: 7370421812296405007},
+			{Name: "Tags", Type: objectbox.PropertyTypeStringVector, Id: 6, Uid: 7370421812296405008},
+		},
+	})
+	model.LastEntityId(1, 7370421812296405001)
+	model.LastIndexId(1, 7370421812296405004)
+	return model
+}
+
+func TestDynamicBox(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(dynamicTestModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	box, err := ob.DynamicBox("PluginRecord")
+	assert.NoErr(t, err)
+
+	_, err = ob.DynamicBox("Unknown")
+	assert.Err(t, err)
+
+	var created = time.Unix(1600000000, 0)
+	var record = objectbox.DynamicObject{
+		"Name":    "first",
+		"Size":    42,
+		"Score":   0.5,
+		"Created": created,
+		"Tags":    []string{"a", "b"},
+	}
+	id, err := box.Put(record)
+	assert.NoErr(t, err)
+	assert.Eq(t, id, record["Id"])
+
+	_, err = box.Put(objectbox.DynamicObject{"Name": "second", "Size": int64(7)})
+	assert.NoErr(t, err)
+
+	// validated against the model
+	_, err = box.Put(objectbox.DynamicObject{"Unknown": 1})
+	assert.Err(t, err)
+	_, err = box.Put(objectbox.DynamicObject{"Size": "not a number"})
+	assert.Err(t, err)
+
+	read, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, objectbox.DynamicObject{
+		"Id":      id,
+		"Name":    "first",
+		"Size":    int32(42),
+		"Score":   0.5,
+		"Created": created,
+		"Tags":    []string{"a", "b"},
+	}, read)
+
+	missing, err := box.Get(id + 100)
+	assert.NoErr(t, err)
+	assert.True(t, missing == nil)
+
+	// queries
+	var find = func(conditions ...objectbox.Condition) []string {
+		objects, err := box.Find(conditions...)
+		assert.NoErr(t, err)
+		var names []string
+		for _, object := range objects {
+			names = append(names, object["Name"].(string))
+		}
+		return names
+	}
+
+	assert.Eq(t, []string{"first"}, find(box.Property("Name").Equals("first")))
+	assert.Eq(t, []string{"second"}, find(box.Property("Size").LessThan(10)))
+	assert.Eq(t, []string{"first"}, find(box.Property("Score").GreaterThan(0.1)))
+	assert.Eq(t, []string{"second"}, find(box.Property("Created").IsNil()))
+	assert.Eq(t, []string{"second", "first"}, find(box.Property("Size").NotEquals(0), box.Property("Size").OrderAsc()))
+
+	_, err = box.Find(box.Property("Unknown").Equals(1))
+	assert.Err(t, err)
+	_, err = box.Find(box.Property("Size").Equals("text"))
+	assert.Err(t, err)
+	_, err = box.Find(box.Property("Tags").Equals([]string{"a"}))
+	assert.Err(t, err)
+}
@@ -0,0 +1,161 @@
+//go:build go1.13
+// +build go1.13
+
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestErrorCodes(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var event = &iot.Event{Uid: "uid"}
+	_, err := box.Put(event)
+	assert.NoErr(t, err)
+
+	_, err = box.Put(&iot.Event{Uid: "uid"})
+	assert.True(t, isError(err, objectbox.ErrUniqueViolation))
+	assert.True(t, !isError(err, objectbox.ErrDbFull))
+
+	obxErr, ok := err.(*objectbox.Error)
+	assert.True(t, ok)
+	assert.Eq(t, objectbox.ErrUniqueViolation.Code(), obxErr.Code())
+	assert.True(t, len(obxErr.Error()) > 0)
+
+	_, err = box.Insert(event)
+	assert.True(t, isError(err, objectbox.ErrIdAlreadyExists))
+
+	assert.NoErr(t, box.Remove(event))
+	err = box.Update(event)
+	assert.True(t, isError(err, objectbox.ErrIdNotFound))
+
+	_, err = box.PutMany([]*iot.Event{{Uid: "uid2"}, {Uid: "uid3"}})
+	assert.NoErr(t, err)
+	_, err = box.Query().FindUnique()
+	assert.True(t, isError(err, objectbox.ErrNonUniqueResult))
+}
+
+// isError checks whether err is an *objectbox.Error with the same code as the target.
+// Note: errors.Is() would do the same but it requires Go 1.13 and the tests must also run with older versions.
+func isError(err error, target *objectbox.Error) bool {
+	obxErr, ok := err.(*objectbox.Error)
+	return ok && obxErr.Is(target)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var open = func(subdir string, policy objectbox.RetryPolicy) *objectbox.ObjectBox {
+		ob, err := objectbox.NewBuilder().Directory(filepath.Join(dir, subdir)).Model(iot.ObjectBoxModel()).
+			MaxReaders(1).NoReaderThreadLocals(true).RetryPolicy(policy).BuildOrError()
+		assert.NoErr(t, err)
+		return ob
+	}
+
+	// occupy the only reader slot from another goroutine (thus OS thread) until release() is called
+	var holdReader = func(ob *objectbox.ObjectBox) (release func()) {
+		var started = make(chan struct{})
+		var released = make(chan struct{})
+		var done = make(chan struct{})
+		go func() {
+			defer close(done)
+			assert.NoErr(t, ob.RunInReadTx(func() error {
+				close(started)
+				<-released
+				return nil
+			}))
+		}()
+		<-started
+		return func() {
+			close(released)
+			<-done
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		ob := open("disabled", objectbox.RetryPolicy{})
+		defer ob.Close()
+
+		var release = holdReader(ob)
+		err := ob.RunInReadTx(func() error { return nil })
+		release()
+		assert.True(t, isError(err, objectbox.ErrMaxReadersExceeded))
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		ob := open("exhausted", objectbox.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond})
+		defer ob.Close()
+
+		var release = holdReader(ob)
+		err := ob.RunInReadTx(func() error { return nil })
+		release()
+		assert.True(t, isError(err, objectbox.ErrMaxReadersExceeded))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ob := open("success", objectbox.RetryPolicy{
+			MaxAttempts:  1000,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     10 * time.Millisecond,
+		})
+		defer ob.Close()
+
+		var release = holdReader(ob)
+		var timer = time.AfterFunc(50*time.Millisecond, release)
+		defer timer.Stop()
+		assert.NoErr(t, ob.RunInReadTx(func() error { return nil }))
+	})
+}
+
+func TestStoreAlreadyOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+
+	_, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	openErr, ok := err.(*objectbox.StoreAlreadyOpenError)
+	assert.True(t, ok)
+	assert.True(t, openErr.Is(objectbox.ErrStoreAlreadyOpen))
+	assert.Eq(t, dir, openErr.Directory)
+
+	// wait for the other store to be closed
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ob.Close()
+	}()
+	ob, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).WaitForLock(5 * time.Second).BuildOrError()
+	assert.NoErr(t, err)
+	ob.Close()
+}
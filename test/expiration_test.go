@@ -0,0 +1,100 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestExpirationWorker(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var now = time.Now().UnixNano() / int64(time.Millisecond)
+	iot.PutEvent(env.ObjectBox, "never expires", 0)
+	iot.PutEvent(env.ObjectBox, "future", now+int64(time.Hour/time.Millisecond))
+	for i := 0; i < 5; i++ {
+		iot.PutEvent(env.ObjectBox, "expired", now-int64(i+1))
+	}
+
+	_, err := env.ObjectBox.StartExpirationWorker(0, objectbox.ExpirationRule{Property: iot.Event_.Date})
+	assert.Err(t, err)
+
+	_, err = env.ObjectBox.StartExpirationWorker(time.Hour)
+	assert.Err(t, err)
+
+	worker, err := env.ObjectBox.StartExpirationWorker(time.Hour,
+		objectbox.ExpirationRule{Property: iot.Event_.Date, BatchSize: 2})
+	assert.NoErr(t, err)
+	defer worker.Stop()
+
+	assert.NoErr(t, worker.RunOnce())
+	assert.NoErr(t, worker.LastError())
+	assert.Eq(t, map[string]uint64{"Event": 5}, worker.Purged())
+
+	events, err := box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(events))
+	assert.Eq(t, "never expires", events[0].Device)
+	assert.Eq(t, "future", events[1].Device)
+
+	// nothing more to remove
+	assert.NoErr(t, worker.RunOnce())
+	assert.Eq(t, map[string]uint64{"Event": 5}, worker.Purged())
+}
+
+func TestExpirationWorkerBackground(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	iot.PutEvents(env.ObjectBox, 3) // dates in 1970, i.e. expired
+
+	worker, err := env.ObjectBox.StartExpirationWorker(10*time.Millisecond,
+		objectbox.ExpirationRule{Property: iot.Event_.Date})
+	assert.NoErr(t, err)
+
+	for i := 0; i < 100; i++ {
+		if worker.Purged()["Event"] == 3 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// stopping repeatedly and concurrently is fine
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker.Stop()
+		}()
+	}
+	wg.Wait()
+	worker.Stop()
+
+	assert.Eq(t, uint64(3), worker.Purged()["Event"])
+	isEmpty, err := box.IsEmpty()
+	assert.NoErr(t, err)
+	assert.True(t, isEmpty)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+)
+
+func TestAESCipher(t *testing.T) {
+	_, err := objectbox.NewAESCipher([]byte("too short"))
+	assert.Err(t, err)
+
+	c, err := objectbox.NewAESCipher(bytes.Repeat([]byte{1}, 32))
+	assert.NoErr(t, err)
+
+	var plaintext = []byte("secret token")
+	first, err := c.Encrypt(plaintext)
+	assert.NoErr(t, err)
+	second, err := c.Encrypt(plaintext)
+	assert.NoErr(t, err)
+	assert.True(t, !bytes.Contains(first, plaintext))
+	assert.True(t, !bytes.Equal(first, second)) // random nonce
+
+	decrypted, err := c.Decrypt(first)
+	assert.NoErr(t, err)
+	assert.Eq(t, plaintext, decrypted)
+
+	// tampered data or a different key are detected
+	first[len(first)-1]++
+	_, err = c.Decrypt(first)
+	assert.Err(t, err)
+
+	other, err := objectbox.NewAESCipher(bytes.Repeat([]byte{2}, 32))
+	assert.NoErr(t, err)
+	_, err = other.Decrypt(second)
+	assert.Err(t, err)
+}
+
+func TestFieldCipher(t *testing.T) {
+	_, err := objectbox.EncryptFieldString("secret token")
+	assert.Eq(t, objectbox.ErrNoFieldCipher, err)
+
+	c, err := objectbox.NewAESCipher(bytes.Repeat([]byte{1}, 16))
+	assert.NoErr(t, err)
+	assert.NoErr(t, objectbox.SetFieldCipher(c))
+	defer objectbox.SetFieldCipher(nil)
+
+	// installing the same cipher again is fine, a different one would break decrypting the existing data
+	assert.NoErr(t, objectbox.SetFieldCipher(c))
+	other, err := objectbox.NewAESCipher(bytes.Repeat([]byte{2}, 16))
+	assert.NoErr(t, err)
+	assert.Err(t, objectbox.SetFieldCipher(other))
+
+	encrypted, err := objectbox.EncryptFieldString("secret token")
+	assert.NoErr(t, err)
+	assert.True(t, !bytes.Contains(encrypted, []byte("secret token")))
+
+	decrypted, err := objectbox.DecryptFieldString(encrypted)
+	assert.NoErr(t, err)
+	assert.Eq(t, "secret token", decrypted)
+
+	// empty values aren't stored at all
+	encrypted, err = objectbox.EncryptFieldBytes(nil)
+	assert.NoErr(t, err)
+	assert.True(t, encrypted == nil)
+
+	decrypted, err = objectbox.DecryptFieldString(nil)
+	assert.NoErr(t, err)
+	assert.Eq(t, "", decrypted)
+}
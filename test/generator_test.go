@@ -0,0 +1,134 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/build"
+)
+
+var updateGenerated = flag.Bool("update", false, "overwrite the committed test model files with the regenerated ones")
+
+// TestGeneratedModelUpToDate regenerates the test models and compares the result with the committed files, so that
+// changes of the generator (or its version) show up as reviewable diffs. Run with -update to accept the changes.
+// The generator runs on a temporary copy of the models so the committed files are never touched unless requested.
+func TestGeneratedModelUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generator run in short mode")
+	}
+
+	var files []string
+	for _, pattern := range []string{"model/*.obx.go", "model/objectbox-model.*", "model/*/*.obx.go",
+		"model/*/objectbox-model.*"} {
+		matches, err := filepath.Glob(pattern)
+		assert.NoErr(t, err)
+		files = append(files, matches...)
+	}
+	assert.True(t, len(files) > 0)
+
+	tempDir, err := ioutil.TempDir("", "objectbox-generator-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(tempDir)
+
+	// the copy is a module of its own, using this repository for the generator and the objectbox package
+	repoDir, err := filepath.Abs("..")
+	assert.NoErr(t, err)
+	assert.NoErr(t, copyDir("model", filepath.Join(tempDir, "model")))
+	assert.NoErr(t, copyFile(filepath.Join(repoDir, "go.sum"), filepath.Join(tempDir, "go.sum")))
+	assert.NoErr(t, ioutil.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module objectbox-generator-test\n\n"+
+		"require github.com/objectbox/objectbox-go v0.0.0\n\n"+
+		"replace github.com/objectbox/objectbox-go => "+filepath.ToSlash(repoDir)+"\n"), 0644))
+
+	_, stdErr, err := build.Generate(filepath.Join(tempDir, "model"), "./...")
+	if err != nil {
+		assert.Failf(t, "generator failed: %s\n%s", err, stdErr)
+	}
+
+	var changed []string
+	for _, file := range files {
+		committed, err := ioutil.ReadFile(file)
+		assert.NoErr(t, err)
+		generated, err := ioutil.ReadFile(filepath.Join(tempDir, file))
+		assert.NoErr(t, err)
+		if line := firstDifferentLine(committed, generated); line > 0 {
+			changed = append(changed, file+":"+strconv.Itoa(line))
+			if *updateGenerated {
+				assert.NoErr(t, ioutil.WriteFile(file, generated, 0644))
+			}
+		}
+	}
+
+	if len(changed) > 0 && !*updateGenerated {
+		assert.Failf(t, "generated files differ from the committed ones (first difference):\n  %s\n"+
+			"run `go test -run TestGeneratedModelUpToDate -update` and review the diff", strings.Join(changed, "\n  "))
+	}
+}
+
+// copyDir recursively copies the given directory
+func copyDir(source, target string) error {
+	return filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(target, relative), 0755)
+		}
+		return copyFile(path, filepath.Join(target, relative))
+	})
+}
+
+func copyFile(source, target string) error {
+	data, err := ioutil.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(target, data, 0644)
+}
+
+// firstDifferentLine returns the 1-based number of the first line which differs, or 0 if the contents are equal
+func firstDifferentLine(a, b []byte) int {
+	if bytes.Equal(a, b) {
+		return 0
+	}
+
+	var linesA = bytes.Split(a, []byte("\n"))
+	var linesB = bytes.Split(b, []byte("\n"))
+	for i := 0; i < len(linesA) && i < len(linesB); i++ {
+		if !bytes.Equal(linesA[i], linesB[i]) {
+			return i + 1
+		}
+	}
+	if len(linesA) < len(linesB) {
+		return len(linesA) + 1
+	}
+	return len(linesB) + 1
+}
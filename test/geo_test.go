@@ -0,0 +1,106 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/geo"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+)
+
+func TestGeoDistanceAndGeohash(t *testing.T) {
+	var munich = geo.Point{Lat: 48.137, Lng: 11.575}
+	var berlin = geo.Point{Lat: 52.52, Lng: 13.405}
+	assert.True(t, math.Abs(geo.Distance(munich, berlin)-504339) < 1)
+	assert.Eq(t, float64(0), geo.Distance(munich, munich))
+
+	assert.Eq(t, "u4pruydqqvj", geo.Geohash(geo.Point{Lat: 57.64911, Lng: 10.40744}, 11))
+	assert.Eq(t, "u281z7", geo.Geohash(munich, 6))
+
+	bb, err := geo.GeohashBoundingBox("u281z7")
+	assert.NoErr(t, err)
+	assert.True(t, bb.Contains(munich))
+	assert.True(t, !bb.Contains(berlin))
+
+	_, err = geo.GeohashBoundingBox("u281a") // "a" is not part of the alphabet
+	assert.Err(t, err)
+}
+
+func TestGeoQueries(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	// Float64 holds the latitude and Float64Ptr the longitude; String the geohash
+	var put = func(name string, p geo.Point) {
+		var lng = p.Lng
+		env.PutEntity(&model.Entity{Float64: p.Lat, Float64Ptr: &lng, String: geo.Geohash(p, 9), StringPtr: &name})
+	}
+	var position = func(object interface{}) geo.Point {
+		return geo.Point{Lat: object.(*model.Entity).Float64, Lng: *object.(*model.Entity).Float64Ptr}
+	}
+	var names = func(objects []*model.Entity) map[string]bool {
+		var result = make(map[string]bool)
+		for _, object := range objects {
+			result[*object.StringPtr] = true
+		}
+		return result
+	}
+
+	var munich = geo.Point{Lat: 48.137, Lng: 11.575}
+	put("munich", munich)
+	put("garching", geo.Point{Lat: 48.249, Lng: 11.651})  // ~14 km
+	put("corner", geo.Point{Lat: 48.307, Lng: 11.825})    // ~26 km, within the bounding box of a 20 km radius
+	put("augsburg", geo.Point{Lat: 48.366, Lng: 10.898})  // ~56 km
+	put("fiji-east", geo.Point{Lat: -17.7, Lng: 179.9})   // antimeridian
+	put("fiji-west", geo.Point{Lat: -17.7, Lng: -179.95}) // antimeridian
+
+	var lat, lng = model.Entity_.Float64, model.Entity_.Float64Ptr
+
+	objects, err := env.Box.Query(geo.WithinRadius(lat, lng, munich, 20000)).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]bool{"munich": true, "garching": true, "corner": true}, names(objects))
+
+	objects = geo.FilterWithinRadius(objects, munich, 20000, position).([]*model.Entity)
+	assert.Eq(t, map[string]bool{"munich": true, "garching": true}, names(objects))
+
+	// bounding box crossing the antimeridian
+	var pacific = geo.BoundingBox{Min: geo.Point{Lat: -20, Lng: 179}, Max: geo.Point{Lat: -15, Lng: -179}}
+	objects, err = env.Box.Query(geo.WithinBoundingBox(lat, lng, pacific)).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]bool{"fiji-east": true, "fiji-west": true}, names(objects))
+
+	objects, err = env.Box.Query(geo.WithinRadius(lat, lng, geo.Point{Lat: -17.7, Lng: 180}, 20000)).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]bool{"fiji-east": true, "fiji-west": true}, names(objects))
+
+	// geohash pre-filter
+	var hashes = geo.GeohashCoverage(geo.RadiusBoundingBox(munich, 20000), 5)
+	objects, err = env.Box.Query(geo.WithinGeohashes(model.Entity_.String, hashes...)).Find()
+	assert.NoErr(t, err)
+	var found = names(objects)
+	assert.True(t, found["munich"] && found["garching"] && found["corner"])
+	assert.True(t, !found["augsburg"] && !found["fiji-east"])
+
+	var conditions = []objectbox.Condition{geo.WithinGeohashes(model.Entity_.String, hashes...), lng.GreaterThan(11.6)}
+	count, err := env.Box.Query(conditions...).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+	"github.com/objectbox/objectbox-go/test/assert"
+)
+
+// hookedNote implements the lifecycle hooks; the binding is written by hand to keep the generated test models simple
+type hookedNote struct {
+	Id        uint64
+	Text      string
+	Revision  int64
+	WordCount int // not stored, computed by AfterGet
+}
+
+func (note *hookedNote) BeforePut() error {
+	if note.Text == "" {
+		return errors.New("text must not be empty")
+	}
+	note.Revision++
+	return nil
+}
+
+func (note *hookedNote) AfterGet() error {
+	note.WordCount = len(strings.Fields(note.Text))
+	return nil
+}
+
+type hookedNote_EntityInfo struct {
+	objectbox.Entity
+}
+
+var hookedNoteBinding = hookedNote_EntityInfo{Entity: objectbox.Entity{Id: 1}}
+
+func (hookedNote_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+func (hookedNote_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("HookedNote", 1, 3591027650212784915)
+	model.Property("Id", 6, 1, 8414513180932561205)
+	model.PropertyFlags(1)
+	model.Property("Text", 9, 2, 4823607412297740154)
+	model.Property("Revision", 6, 3, 1276344560290814203)
+	model.EntityLastPropertyId(3, 1276344560290814203)
+}
+
+func (hookedNote_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*hookedNote).Id, nil
+}
+
+func (hookedNote_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*hookedNote).Id = id
+	return nil
+}
+
+func (hookedNote_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+func (hookedNote_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*hookedNote)
+	var offsetText = fbutils.CreateStringOffset(fbb, obj.Text)
+
+	fbb.StartObject(3)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetText)
+	fbutils.SetInt64Slot(fbb, 2, obj.Revision)
+	return nil
+}
+
+func (hookedNote_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	return &hookedNote{
+		Id:       fbutils.GetUint64Slot(table, 4),
+		Text:     fbutils.GetStringSlot(table, 6),
+		Revision: fbutils.GetInt64Slot(table, 8),
+	}, nil
+}
+
+func (hookedNote_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*hookedNote, 0, capacity)
+}
+
+func (hookedNote_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	return append(slice.([]*hookedNote), object.(*hookedNote))
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var model = objectbox.NewModel()
+	model.GeneratorVersion(6)
+	model.RegisterBinding(hookedNoteBinding)
+	model.LastEntityId(1, 3591027650212784915)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(model).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	var box = ob.InternalBox(1)
+
+	var note = &hookedNote{Text: "hello world"}
+	id, err := box.Put(note)
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(1), note.Revision)
+
+	_, err = box.Put(note)
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(2), note.Revision)
+
+	object, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, &hookedNote{Id: id, Text: "hello world", Revision: 2, WordCount: 2}, object)
+
+	_, err = box.PutMany([]*hookedNote{{Text: "one"}, {Text: "two three four"}})
+	assert.NoErr(t, err)
+
+	slice, err := box.Query().Find()
+	assert.NoErr(t, err)
+	var revisions []int64
+	var words []int
+	for _, note := range slice.([]*hookedNote) {
+		revisions = append(revisions, note.Revision)
+		words = append(words, note.WordCount)
+	}
+	assert.Eq(t, []int64{2, 1, 1}, revisions)
+	assert.Eq(t, []int{2, 1, 3}, words)
+
+	// an error returned by BeforePut aborts the put
+	_, err = box.Put(&hookedNote{})
+	assert.Err(t, err)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+}
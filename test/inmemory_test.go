@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/objectboxtest"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestInMemory(t *testing.T) {
+	ob, err := objectbox.NewBuilder().InMemory("in-memory-test").Model(iot.ObjectBoxModel()).BuildOrError()
+	if !objectbox.InMemoryIsAvailable() {
+		assert.Err(t, err)
+		assert.True(t, strings.Contains(err.Error(), "in-memory"))
+		return
+	}
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	box := iot.BoxForEvent(ob)
+	_, err = box.Put(&iot.Event{Device: "in-memory"})
+	assert.NoErr(t, err)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+}
+
+func TestNewTestStore(t *testing.T) {
+	ob, cleanup := objectboxtest.NewTestStore(t, iot.ObjectBoxModel())
+	defer cleanup()
+
+	box := iot.BoxForEvent(ob)
+	isEmpty, err := box.IsEmpty()
+	assert.NoErr(t, err)
+	assert.True(t, isEmpty)
+
+	_, err = box.Put(&iot.Event{Device: "test-store"})
+	assert.NoErr(t, err)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestBoxJSON(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	_, err := box.PutMany([]*iot.Event{{Device: "Pi 3B", Date: 1}, {Device: "Pi Zero", Date: 2}})
+	assert.NoErr(t, err)
+
+	var buffer bytes.Buffer
+	assert.NoErr(t, box.ExportJSON(&buffer))
+	var lines = strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Eq(t, 2, len(lines))
+	assert.True(t, strings.Contains(lines[1], `"Pi Zero"`))
+
+	var exported = buffer.String()
+
+	// inserting the same IDs fails and the whole import is rolled back
+	count, err := box.ImportJSON(strings.NewReader(exported), objectbox.JSONImportInsert)
+	assert.Err(t, err)
+	assert.Eq(t, uint64(0), count)
+
+	// upsert overwrites the existing objects
+	count, err = box.ImportJSON(strings.NewReader(strings.Replace(exported, "Pi Zero", "Pi 4", 1)), objectbox.JSONImportUpsert)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	event, err := box.Get(2)
+	assert.NoErr(t, err)
+	assert.Eq(t, "Pi 4", event.Device)
+
+	// new objects get new IDs
+	count, err = box.ImportJSON(strings.NewReader(exported), objectbox.JSONImportNew)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	all, err := box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 4, len(all))
+	assert.Eq(t, "Pi Zero", all[3].Device)
+	assert.Eq(t, int64(2), all[3].Date)
+
+	// invalid input
+	_, err = box.ImportJSON(strings.NewReader(`{"Device": `), objectbox.JSONImportNew)
+	assert.Err(t, err)
+}
+
+func TestBoxJSONByValue(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	box := model.BoxForEntityByValue(env.ObjectBox)
+
+	_, err := box.PutMany([]model.EntityByValue{{Text: "a"}, {Text: "b"}})
+	assert.NoErr(t, err)
+
+	var buffer bytes.Buffer
+	assert.NoErr(t, box.ExportJSON(&buffer))
+
+	count, err := box.ImportJSON(strings.NewReader(strings.Replace(buffer.String(), `"b"`, `"c"`, 1)), objectbox.JSONImportUpsert)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	count, err = box.ImportJSON(strings.NewReader(buffer.String()), objectbox.JSONImportNew)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	all, err := box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 4, len(all))
+	assert.Eq(t, "a", all[0].Text)
+	assert.Eq(t, "c", all[1].Text)
+	assert.Eq(t, uint64(3), all[2].Id)
+	assert.Eq(t, "a", all[2].Text)
+	assert.Eq(t, "b", all[3].Text)
+}
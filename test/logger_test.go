@@ -0,0 +1,44 @@
+//go:build go1.21
+// +build go1.21
+
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	var logger = objectbox.NewSlogLogger(slog.New(slog.NewTextHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	logger.Log(objectbox.LogLevelDebug, "skipped")
+	logger.Log(objectbox.LogLevelError, "error in Query finalizer", "entity", "Task", "code", 10001)
+
+	var output = buffer.String()
+	assert.True(t, !strings.Contains(output, "skipped"))
+	assert.True(t, strings.Contains(output, `level=ERROR msg="error in Query finalizer" entity=Task code=10001`))
+
+	assert.Eq(t, "WARN", objectbox.LogLevelWarn.String())
+}
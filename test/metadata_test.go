@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"testing"
+
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestEntityMetadata(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	assert.Eq(t, []string{"Event", "Reading", "SchemaVersion"}, env.ObjectBox.EntityNames())
+
+	metadata, err := env.ObjectBox.EntityMetadata("Event")
+	assert.NoErr(t, err)
+	assert.Eq(t, iot.EventBinding.Id, metadata.Id)
+	assert.Eq(t, "Event", metadata.Name)
+
+	var names []string
+	var types []string
+	for _, prop := range metadata.Properties {
+		names = append(names, prop.Name)
+		types = append(types, prop.TypeName())
+	}
+	assert.Eq(t, []string{"Id", "Device", "Date", "Uid", "Picture"}, names)
+	assert.Eq(t, []string{"Long", "String", "Date", "String", "ByteVector"}, types)
+
+	var id = metadata.Properties[0]
+	assert.Eq(t, iot.Event_.Id.Id, id.Id)
+	assert.True(t, id.IsId())
+	assert.True(t, !id.IsIndexed())
+
+	var uid = metadata.Properties[3]
+	assert.Eq(t, iot.Event_.Uid.Id, uid.Id)
+	assert.True(t, !uid.IsId())
+	assert.True(t, uid.IsIndexed())
+	assert.True(t, uid.IsUnique())
+
+	_, err = env.ObjectBox.EntityMetadata("Unknown")
+	assert.Err(t, err)
+}
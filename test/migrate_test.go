@@ -0,0 +1,66 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox/migrate"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestBatchUpdate(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var box = iot.BoxForEvent(env.ObjectBox)
+	iot.PutEvents(env.ObjectBox, 25)
+
+	// update events with an even date, among those with a date greater than 10002
+	var query = box.Query(iot.Event_.Date.GreaterThan(10002))
+	var reported []migrate.Progress
+	result, err := migrate.BatchUpdateWithProgress(box.Box, query.Query, 10, func(object interface{}) bool {
+		var event = object.(*iot.Event)
+		if event.Date%2 == 1 {
+			return false
+		}
+		event.Device = "migrated"
+		return true
+	}, func(progress migrate.Progress) {
+		reported = append(reported, progress)
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, migrate.Progress{Total: 23, Processed: 23, Changed: 11}, result)
+	assert.Eq(t, []migrate.Progress{
+		{Total: 23, Processed: 10, Changed: 5},
+		{Total: 23, Processed: 20, Changed: 10},
+		{Total: 23, Processed: 23, Changed: 11},
+	}, reported)
+
+	migrated, err := box.Query(iot.Event_.Device.Equals("migrated", true)).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(11), migrated)
+
+	// nothing changed
+	result, err = migrate.BatchUpdate(box.Box, query.Query, 100, func(object interface{}) bool { return false })
+	assert.NoErr(t, err)
+	assert.Eq(t, migrate.Progress{Total: 23, Processed: 23}, result)
+
+	_, err = migrate.BatchUpdate(box.Box, query.Query, 0, func(object interface{}) bool { return false })
+	assert.Err(t, err)
+}
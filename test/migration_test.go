@@ -0,0 +1,144 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestMigrations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var builder = func() *objectbox.Builder {
+		return objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).
+			SchemaVersionProperty(iot.SchemaVersion_.Version)
+	}
+	var count = func(ob *objectbox.ObjectBox) uint64 {
+		count, err := iot.BoxForEvent(ob).Count()
+		assert.NoErr(t, err)
+		return count
+	}
+	var putEvent = func(tx *objectbox.MigrationTx) error {
+		_, err := iot.BoxForEvent(tx.ObjectBox()).Put(&iot.Event{Device: "migrated"})
+		return err
+	}
+
+	// no migrations
+	ob, err := builder().BuildOrError()
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, ob.SchemaVersion())
+	ob.Close()
+
+	// steps run in order
+	var steps []string
+	ob, err = builder().
+		MigrateTo(1, func(tx *objectbox.MigrationTx) error {
+			steps = append(steps, "1")
+			assert.Eq(t, 0, tx.From)
+			assert.Eq(t, 1, tx.To)
+			return putEvent(tx)
+		}).
+		MigrateTo(2, func(tx *objectbox.MigrationTx) error {
+			steps = append(steps, "2")
+			assert.Eq(t, 1, tx.From)
+			assert.Eq(t, uint64(1), count(tx.ObjectBox()))
+			return putEvent(tx)
+		}).BuildOrError()
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"1", "2"}, steps)
+	assert.Eq(t, 2, ob.SchemaVersion())
+	assert.Eq(t, uint64(2), count(ob))
+	ob.Close()
+
+	// only new steps run after reopening; a failed step is rolled back and fails opening the store
+	var expected = errors.New("expected")
+	var failStep = func(tx *objectbox.MigrationTx) error {
+		assert.NoErr(t, putEvent(tx))
+		return expected
+	}
+	steps = nil
+	ob, err = builder().
+		MigrateTo(2, func(tx *objectbox.MigrationTx) error {
+			steps = append(steps, "2")
+			return nil
+		}).
+		MigrateTo(3, func(tx *objectbox.MigrationTx) error {
+			steps = append(steps, "3")
+			return putEvent(tx)
+		}).
+		MigrateTo(4, failStep).BuildOrError()
+	assert.Err(t, err)
+	assert.True(t, strings.Contains(err.Error(), "migration 4"))
+	assert.True(t, strings.Contains(err.Error(), expected.Error()))
+	assert.Eq(t, []string{"3"}, steps)
+
+	ob, err = builder().BuildOrError()
+	assert.NoErr(t, err)
+	assert.Eq(t, 3, ob.SchemaVersion())
+	assert.Eq(t, uint64(3), count(ob))
+
+	// the version is kept in a single object, updated by each step
+	versions, err := iot.BoxForSchemaVersion(ob).GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(versions))
+	assert.Eq(t, int64(3), versions[0].Version)
+
+	// opening fails if it's not clear which object holds the version
+	extraId, err := iot.BoxForSchemaVersion(ob).Put(&iot.SchemaVersion{Version: 5})
+	assert.NoErr(t, err)
+	ob.Close()
+	_, err = builder().BuildOrError()
+	assert.Err(t, err)
+	assert.True(t, strings.Contains(err.Error(), "single object"))
+
+	ob, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	assert.NoErr(t, iot.BoxForSchemaVersion(ob).RemoveId(extraId))
+	ob.Close()
+
+	// without the version property, the version isn't known and steps can't run
+	ob, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, ob.SchemaVersion())
+	ob.Close()
+	_, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).MigrateTo(5, putEvent).BuildOrError()
+	assert.Err(t, err)
+
+	// invalid steps
+	_, err = builder().MigrateTo(0, putEvent).BuildOrError()
+	assert.Err(t, err)
+	_, err = builder().MigrateTo(5, putEvent).MigrateTo(5, putEvent).BuildOrError()
+	assert.Err(t, err)
+	_, err = builder().MigrateTo(5, nil).BuildOrError()
+	assert.Err(t, err)
+	_, err = builder().SchemaVersionProperty(iot.Event_.Date).BuildOrError()
+	assert.Err(t, err)
+	_, err = builder().SchemaVersionProperty(iot.Reading_.ValueInteger).BuildOrError() // other properties
+	assert.Err(t, err)
+	_, err = builder().SchemaVersionProperty(nil).BuildOrError()
+	assert.Err(t, err)
+}
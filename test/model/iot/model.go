@@ -52,3 +52,9 @@ type Reading struct {
 	/// Device sensor data value
 	ValueFloating32 float32
 }
+
+// SchemaVersion model, dedicated to storing the schema version of migrations
+type SchemaVersion struct {
+	Id      uint64 `objectbox:"id"`
+	Version int64
+}
@@ -773,3 +773,329 @@ func (query *ReadingQuery) Limit(limit uint64) *ReadingQuery {
 	query.Query.Limit(limit)
 	return query
 }
+
+type schemaVersion_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var SchemaVersionBinding = schemaVersion_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 3,
+	},
+	Uid: 2292805961358661703,
+}
+
+// SchemaVersion_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var SchemaVersion_ = struct {
+	Id      *objectbox.PropertyUint64
+	Version *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &SchemaVersionBinding.Entity,
+		},
+	},
+	Version: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &SchemaVersionBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (schemaVersion_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (schemaVersion_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("SchemaVersion", 3, 2292805961358661703)
+	model.Property("Id", 6, 1, 1141191427078660641)
+	model.PropertyFlags(1)
+	model.Property("Version", 6, 2, 7268175138706605512)
+	model.EntityLastPropertyId(2, 7268175138706605512)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (schemaVersion_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*SchemaVersion).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (schemaVersion_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*SchemaVersion).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (schemaVersion_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (schemaVersion_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*SchemaVersion)
+
+	// build the FlatBuffers object
+	fbb.StartObject(2)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetInt64Slot(fbb, 1, obj.Version)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (schemaVersion_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'SchemaVersion' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	return &SchemaVersion{
+		Id:      propId,
+		Version: fbutils.GetInt64Slot(table, 6),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (schemaVersion_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*SchemaVersion, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (schemaVersion_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*SchemaVersion), nil)
+	}
+	return append(slice.([]*SchemaVersion), object.(*SchemaVersion))
+}
+
+// Box provides CRUD access to SchemaVersion objects
+type SchemaVersionBox struct {
+	*objectbox.Box
+}
+
+// BoxForSchemaVersion opens a box of SchemaVersion objects
+func BoxForSchemaVersion(ob *objectbox.ObjectBox) *SchemaVersionBox {
+	return &SchemaVersionBox{
+		Box: ob.InternalBox(3),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the SchemaVersion.Id property on the passed object will be assigned the new ID as well.
+func (box *SchemaVersionBox) Put(object *SchemaVersion) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the SchemaVersion.Id property on the passed object will be assigned the new ID as well.
+func (box *SchemaVersionBox) Insert(object *SchemaVersion) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *SchemaVersionBox) Update(object *SchemaVersion) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *SchemaVersionBox) PutAsync(object *SchemaVersion) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the SchemaVersion.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the SchemaVersion.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *SchemaVersionBox) PutMany(objects []*SchemaVersion) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *SchemaVersionBox) Get(id uint64) (*SchemaVersion, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*SchemaVersion), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *SchemaVersionBox) GetMany(ids ...uint64) ([]*SchemaVersion, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SchemaVersion), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *SchemaVersionBox) GetManyExisting(ids ...uint64) ([]*SchemaVersion, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SchemaVersion), nil
+}
+
+// GetAll reads all stored objects
+func (box *SchemaVersionBox) GetAll() ([]*SchemaVersion, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SchemaVersion), nil
+}
+
+// Remove deletes a single object
+func (box *SchemaVersionBox) Remove(object *SchemaVersion) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *SchemaVersionBox) RemoveMany(objects ...*SchemaVersion) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the SchemaVersion_ struct to create conditions.
+// Keep the *SchemaVersionQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *SchemaVersionBox) Query(conditions ...objectbox.Condition) *SchemaVersionQuery {
+	return &SchemaVersionQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the SchemaVersion_ struct to create conditions.
+// Keep the *SchemaVersionQuery if you intend to execute the query multiple times.
+func (box *SchemaVersionBox) QueryOrError(conditions ...objectbox.Condition) (*SchemaVersionQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &SchemaVersionQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See SchemaVersionAsyncBox for more information.
+func (box *SchemaVersionBox) Async() *SchemaVersionAsyncBox {
+	return &SchemaVersionAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// SchemaVersionAsyncBox provides asynchronous operations on SchemaVersion objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type SchemaVersionAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForSchemaVersion creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use SchemaVersionBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForSchemaVersion(ob *objectbox.ObjectBox, timeoutMs uint64) *SchemaVersionAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 3, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 3: %s" + err.Error())
+	}
+	return &SchemaVersionAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *SchemaVersionAsyncBox) Put(object *SchemaVersion) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *SchemaVersionAsyncBox) Insert(object *SchemaVersion) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *SchemaVersionAsyncBox) Update(object *SchemaVersion) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *SchemaVersionAsyncBox) Remove(object *SchemaVersion) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all SchemaVersion which Id is either 42 or 47:
+// 		box.Query(SchemaVersion_.Id.In(42, 47)).Find()
+type SchemaVersionQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *SchemaVersionQuery) Find() ([]*SchemaVersion, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SchemaVersion), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *SchemaVersionQuery) Offset(offset uint64) *SchemaVersionQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *SchemaVersionQuery) Limit(limit uint64) *SchemaVersionQuery {
+	query.Query.Limit(limit)
+	return query
+}
@@ -14,7 +14,8 @@ func ObjectBoxModel() *objectbox.Model {
 
 	model.RegisterBinding(EventBinding)
 	model.RegisterBinding(ReadingBinding)
-	model.LastEntityId(2, 5284076134434938613)
+	model.RegisterBinding(SchemaVersionBinding)
+	model.LastEntityId(3, 2292805961358661703)
 	model.LastIndexId(2, 2642563953244304959)
 
 	return model
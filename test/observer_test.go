@@ -0,0 +1,117 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestObserver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var mutex sync.Mutex
+	var events []objectbox.OperationEvent
+	var reset = func() {
+		mutex.Lock()
+		events = nil
+		mutex.Unlock()
+	}
+	var find = func(operation objectbox.Operation) []objectbox.OperationEvent {
+		mutex.Lock()
+		defer mutex.Unlock()
+		var result []objectbox.OperationEvent
+		for _, event := range events {
+			if event.Operation == operation {
+				result = append(result, event)
+			}
+		}
+		return result
+	}
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).
+		Observer(func(event objectbox.OperationEvent) {
+			mutex.Lock()
+			events = append(events, event)
+			mutex.Unlock()
+		}).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	box := iot.BoxForEvent(ob)
+
+	id, err := box.Put(&iot.Event{Device: "dev"})
+	assert.NoErr(t, err)
+	var puts = find(objectbox.OperationPut)
+	assert.Eq(t, 1, len(puts))
+	assert.Eq(t, "Event", puts[0].Entity)
+	assert.Eq(t, 1, puts[0].Count)
+	assert.True(t, puts[0].Bytes > 0)
+	assert.NoErr(t, puts[0].Err)
+
+	reset()
+	_, err = box.PutMany([]*iot.Event{{Device: "a"}, {Device: "b"}})
+	assert.NoErr(t, err)
+	puts = find(objectbox.OperationPut)
+	assert.Eq(t, 1, len(puts))
+	assert.Eq(t, 2, puts[0].Count)
+	assert.Eq(t, 1, len(find(objectbox.OperationWriteTx)))
+
+	reset()
+	_, err = box.Get(id)
+	assert.NoErr(t, err)
+	_, err = box.GetAll()
+	assert.NoErr(t, err)
+	var gets = find(objectbox.OperationGet)
+	assert.Eq(t, 2, len(gets))
+	assert.Eq(t, 1, gets[0].Count)
+	assert.Eq(t, 3, gets[1].Count)
+	assert.True(t, len(find(objectbox.OperationReadTx)) > 0)
+
+	reset()
+	count, err := box.Query(iot.Event_.Device.Equals("a", true)).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+	var queries = find(objectbox.OperationQuery)
+	assert.Eq(t, 1, len(queries))
+	assert.Eq(t, 1, queries[0].Count)
+
+	reset()
+	assert.NoErr(t, box.RemoveId(id))
+	removed, err := box.Query().Limit(1).Remove()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), removed)
+	var removes = find(objectbox.OperationRemove)
+	assert.Eq(t, 2, len(removes))
+	assert.Eq(t, 1, removes[1].Count)
+	assert.Eq(t, 0, len(find(objectbox.OperationQuery)))
+
+	// failed operations are reported as well
+	reset()
+	assert.Err(t, box.Update(&iot.Event{Id: id, Device: "removed"}))
+	puts = find(objectbox.OperationPut)
+	assert.Eq(t, 1, len(puts))
+	assert.Err(t, puts[0].Err)
+}
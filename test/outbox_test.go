@@ -0,0 +1,85 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+)
+
+func TestOutbox(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	_, err := env.ObjectBox.NewOutbox(nil, nil)
+	assert.Err(t, err)
+
+	// the Entity box serves as the outbox in this test
+	outbox, err := env.ObjectBox.NewOutbox(model.Entity_.ByteVector, model.Entity_.Date)
+	assert.NoErr(t, err)
+
+	var pending = func() uint64 {
+		count, err := outbox.Pending()
+		assert.NoErr(t, err)
+		return count
+	}
+
+	var start = time.Now().Add(-time.Second)
+	assert.NoErr(t, outbox.Write(func() error { return nil }, []byte("first"), []byte("second")))
+	assert.Eq(t, uint64(2), pending())
+
+	// messages are only stored if the transaction is committed
+	var expected = errors.New("expected")
+	assert.Eq(t, expected, outbox.Write(func() error { return expected }, []byte("lost")))
+	assert.Eq(t, expected, env.ObjectBox.RunInWriteTx(func() error {
+		assert.NoErr(t, outbox.Add([]byte("lost")))
+		return expected
+	}))
+	assert.Eq(t, uint64(2), pending())
+
+	messages, err := outbox.Poll(1)
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(messages))
+	assert.Eq(t, "first", string(messages[0].Payload))
+	assert.True(t, messages[0].CreatedAt.After(start))
+
+	// polling doesn't remove messages
+	messages, err = outbox.Poll(10)
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(messages))
+	assert.Eq(t, "second", string(messages[1].Payload))
+
+	assert.NoErr(t, outbox.Ack(messages[0].Id))
+	assert.Eq(t, uint64(1), pending())
+
+	messages, err = outbox.Poll(10)
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(messages))
+	assert.Eq(t, "second", string(messages[0].Payload))
+
+	assert.NoErr(t, outbox.Ack(messages[0].Id))
+	messages, err = outbox.Poll(10)
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(messages))
+
+	_, err = outbox.Poll(0)
+	assert.Err(t, err)
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestBoxPatch(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForReading(env.ObjectBox)
+	var R = iot.Reading_
+
+	var reading = &iot.Reading{
+		Date:            1000,
+		EventId:         5,
+		ValueName:       "temperature",
+		ValueString:     "warm",
+		ValueInteger:    -3,
+		ValueFloating:   1.5,
+		ValueInt32:      -7,
+		ValueFloating32: 2.5,
+	}
+	id, err := box.Put(reading)
+	assert.NoErr(t, err)
+
+	var date = time.Unix(1600000000, 0)
+	found, err := box.Patch(id,
+		objectbox.Set(R.ValueString, "hot"),
+		objectbox.Set(R.ValueInteger, 42),
+		objectbox.Set(R.ValueFloating32, float32(-0.5)),
+		objectbox.Set(R.Date, date))
+	assert.NoErr(t, err)
+	assert.True(t, found)
+
+	reading.ValueString = "hot"
+	reading.ValueInteger = 42
+	reading.ValueFloating32 = -0.5
+	reading.Date = date.UnixNano() / int64(time.Millisecond)
+	actual, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, reading, actual)
+
+	// nil removes the value
+	found, err = box.Patch(id, objectbox.Set(R.ValueName, nil))
+	assert.NoErr(t, err)
+	assert.True(t, found)
+	reading.ValueName = ""
+	actual, err = box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, reading, actual)
+
+	found, err = box.Patch(id+1, objectbox.Set(R.ValueString, "none"))
+	assert.NoErr(t, err)
+	assert.True(t, !found)
+
+	_, err = box.Patch(id, objectbox.Set(R.ValueString, 1))
+	assert.Err(t, err)
+
+	_, err = box.Patch(id, objectbox.Set(R.Id, uint64(100)))
+	assert.Err(t, err)
+
+	_, err = box.Patch(id, objectbox.Set(iot.Event_.Device, "other entity"))
+	assert.Err(t, err)
+}
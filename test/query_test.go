@@ -17,17 +17,25 @@
 package objectbox_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/flatbuffers/go"
 	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
 	"github.com/objectbox/objectbox-go/test/assert"
 	"github.com/objectbox/objectbox-go/test/model"
+	"github.com/objectbox/objectbox-go/test/model/iot"
 )
 
 // Following methods use many test-cases defined as a list of queryTestCase and run all Query.* methods on each test case
@@ -51,6 +59,8 @@ func TestQueries(t *testing.T) {
 		{998, s{`String !=(i) "Val-1"`}, box.Query(E.String.NotEquals(e.String, false)), nil},
 		{64, s{`String contains "Val-1"`}, box.Query(E.String.Contains(e.String, true)), nil},
 		{131, s{`String contains(i) "Val-1"`}, box.Query(E.String.Contains(e.String, false)), nil},
+		{64, s{`(String contains "Val-1" OR String contains "Val-1")`}, box.Query(E.String.ContainsAnyOf(true, e.String, e.String)), nil},
+		{131, s{`(String contains(i) "Val-1" AND String contains(i) "-1")`}, box.Query(E.String.ContainsAllOf(false, e.String, "-1")), nil},
 		{64, s{`String starts with "Val-1"`}, box.Query(E.String.HasPrefix(e.String, true)), nil},
 		{131, s{`String starts with(i) "Val-1"`}, box.Query(E.String.HasPrefix(e.String, false)), nil},
 		{1, s{`String ends with "Val-1"`}, box.Query(E.String.HasSuffix(e.String, true)), nil},
@@ -220,6 +230,7 @@ func TestQueries(t *testing.T) {
 		{502, s{`Float64 <= 47.740000`}, box.Query(E.Float64.LessOrEqual(e.Float64)), nil},
 		{1, s{`Float64 between -1.000000 and 1.000000`}, box.Query(E.Float64.Between(-1, 1)), nil},
 		{2, s{`Float64 between 47.740000 and 95.480000`}, box.Query(E.Float64.Between(e.Float64, e.Float64*2)), nil},
+		{2, s{`Float64 between 47.739999 and 47.740001`}, box.Query(E.Float64.Equals(e.Float64, 0.000001)), nil},
 
 		{2, s{`Float32 between 47.739990 and 47.740013`}, box.Query(E.Float32.Between(e.Float32-0.00001, e.Float32+0.00001)), nil},
 		{498, s{`Float32 > 47.740002`}, box.Query(E.Float32.GreaterThan(e.Float32)), nil},
@@ -228,6 +239,7 @@ func TestQueries(t *testing.T) {
 		{502, s{`Float32 <= 47.740002`}, box.Query(E.Float32.LessOrEqual(e.Float32)), nil},
 		{1, s{`Float32 between -1.000000 and 1.000000`}, box.Query(E.Float32.Between(-1, 1)), nil},
 		{2, s{`Float32 between 47.740002 and 95.480003`}, box.Query(E.Float32.Between(e.Float32, e.Float32*2)), nil},
+		{2, s{`Float32 between 47.739992 and 47.740012`}, box.Query(E.Float32.Equals(e.Float32, 0.00001)), nil},
 
 		{6, s{`ByteVector == byte[5]{0x01020305 08}`}, box.Query(E.ByteVector.Equals(e.ByteVector)), nil},
 		// {994, s{`ByteVector != byte[5]{0x01020305 08`}, box.Query(E.ByteVector.NotEquals(e.ByteVector)), nil},
@@ -247,7 +259,7 @@ func TestQueryOffsetLimit(t *testing.T) {
 	env := model.NewTestEnv(t)
 	defer env.Close()
 
-	// skip Count() and Remove() - not supported in combination with `offset`
+	// skip Count() - not supported in combination with `offset`; Remove() is tested in TestQueryRemoveOffsetLimit
 	testQueries(t, env, queryTestOptions{baseCount: 10, skipCount: true, skipRemove: true}, []queryTestCase{
 		{10, s{`TRUE`}, env.Box.Query(), nil},
 		{5, s{`TRUE`}, env.Box.Query().Offset(5), nil},
@@ -268,10 +280,333 @@ func TestQueryOffsetLimit(t *testing.T) {
 	}
 
 	assertNotSupported(env.Box.Query().Offset(5).Count())
-	assertNotSupported(env.Box.Query().Offset(5).Remove())
 	assertNotSupported(env.Box.Query().Offset(1).Limit(2).Count())
-	assertNotSupported(env.Box.Query().Offset(1).Limit(2).Remove())
-	assertNotSupported(env.Box.Query().Limit(5).Remove())
+}
+
+func TestQueryCountMaxExists(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(10)
+
+	var query = env.Box.Query()
+
+	count, err := query.CountMax(3)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+
+	count, err = query.CountMax(100)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(10), count)
+
+	// a lower limit set by the user still applies and is kept
+	count, err = query.Limit(2).CountMax(5)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	count, err = query.Limit(0).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(10), count)
+
+	_, err = query.CountMax(0)
+	assert.Err(t, err)
+
+	exists, err := query.Exists()
+	assert.NoErr(t, err)
+	assert.True(t, exists)
+
+	exists, err = env.Box.Query(model.Entity_.String.Equals("missing", true)).Exists()
+	assert.NoErr(t, err)
+	assert.True(t, !exists)
+}
+
+func TestQueryMaxResultsTimeout(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(10)
+
+	var query = env.Box.Query().MaxResults(5)
+
+	_, err := query.Find()
+	assert.Eq(t, objectbox.ErrMaxResultsExceeded, err)
+
+	_, err = query.FindIds()
+	assert.Eq(t, objectbox.ErrMaxResultsExceeded, err)
+
+	// the limit pushed down to find the IDs is reset afterwards
+	count, err := query.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(10), count)
+
+	// the limit pushed down to find the IDs doesn't replace the one set by the user
+	ids, err := query.Limit(5).FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, 5, len(ids))
+
+	ids, err = query.Offset(2).Limit(0).MaxResults(8).FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, 8, len(ids))
+	query.Offset(0).MaxResults(5)
+
+	var visited int
+	err = query.FindRaw(func(bytes []byte) bool {
+		visited++
+		return true
+	})
+	assert.Eq(t, objectbox.ErrMaxResultsExceeded, err)
+	assert.Eq(t, 5, visited)
+
+	// the result isn't truncated when the maximum isn't exceeded; limit is applied first
+	objects, err := query.Limit(5).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 5, len(objects.([]*model.Entity)))
+
+	objects, err = query.Limit(0).MaxResults(10).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 10, len(objects.([]*model.Entity)))
+
+	// an already expired timeout stops reading at the first object
+	_, err = env.Box.Query().Timeout(time.Nanosecond).Find()
+	assert.Eq(t, context.DeadlineExceeded, err)
+
+	objects, err = env.Box.Query().Timeout(time.Minute).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 10, len(objects.([]*model.Entity)))
+}
+
+func TestQueryRemoveOffsetLimit(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(10)
+
+	var query = env.Box.Query(model.Entity_.Id.OrderAsc()).Offset(1).Limit(3)
+
+	ids, err := query.RemoveDryRun()
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{2, 3, 4}, ids)
+
+	count, err := env.Box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(10), count)
+
+	removed, err := query.Remove()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), removed)
+
+	contains, err := env.Box.ContainsIds(1, 5)
+	assert.NoErr(t, err)
+	assert.True(t, contains)
+
+	contains, err = env.Box.ContainsIds(2)
+	assert.NoErr(t, err)
+	assert.True(t, !contains)
+
+	// the oldest N objects
+	removed, err = env.Box.Query().Limit(2).Remove()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), removed)
+
+	ids, err = env.Box.Query().FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{6, 7, 8, 9, 10}, ids)
+}
+
+func TestQueryFindFirstUnique(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(10)
+
+	object, err := env.Box.Query(model.Entity_.Id.GreaterThan(4)).FindFirst()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(5), object.(*model.Entity).Id)
+
+	object, err = env.Box.Query(model.Entity_.Id.Equals(3)).FindUnique()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), object.(*model.Entity).Id)
+
+	object, err = env.Box.Query(model.Entity_.Id.GreaterThan(100)).FindFirst()
+	assert.NoErr(t, err)
+	assert.True(t, object == nil)
+
+	object, err = env.Box.Query(model.Entity_.Id.GreaterThan(100)).FindUnique()
+	assert.NoErr(t, err)
+	assert.True(t, object == nil)
+
+	object, err = env.Box.Query().FindUnique()
+	assert.Err(t, err)
+	assert.True(t, object == nil)
+}
+
+func TestQueryFindRaw(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+	iot.PutEvents(env.ObjectBox, 3)
+
+	var devices []string
+	assert.NoErr(t, box.Query().FindRaw(func(bytes []byte) bool {
+		var table = &flatbuffers.Table{Bytes: bytes, Pos: flatbuffers.GetUOffsetT(bytes)}
+		devices = append(devices, fbutils.GetStringSlot(table, 6))
+		return len(devices) < 2
+	}))
+	assert.Eq(t, []string{"device 1", "device 2"}, devices)
+
+	var device string
+	found, err := box.GetRaw(3, func(bytes []byte) error {
+		var table = &flatbuffers.Table{Bytes: bytes, Pos: flatbuffers.GetUOffsetT(bytes)}
+		device = fbutils.GetStringSlot(table, 6)
+		return nil
+	})
+	assert.NoErr(t, err)
+	assert.True(t, found)
+	assert.Eq(t, "device 3", device)
+
+	found, err = box.GetRaw(4, func(bytes []byte) error {
+		assert.Fail(t, "called for a missing object")
+		return nil
+	})
+	assert.NoErr(t, err)
+	assert.True(t, !found)
+
+	found, err = box.GetRaw(1, func(bytes []byte) error { return errors.New("callback error") })
+	assert.Err(t, err)
+	assert.True(t, !found)
+}
+
+func TestQueryPageAfter(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(10)
+
+	query := env.Box.Query()
+
+	var ids []uint64
+	var lastId uint64
+	for pages := 0; ; pages++ {
+		page, err := query.PageAfter(lastId, 4)
+		assert.NoErr(t, err)
+
+		for _, object := range page.Objects.([]*model.Entity) {
+			ids = append(ids, object.Id)
+		}
+		lastId = page.LastId
+
+		// insert a new object while paging - it's picked up on the last page without shifting the others
+		if pages == 0 {
+			env.PutEntity(model.Entity47())
+		}
+
+		if !page.HasNext {
+			assert.Eq(t, 2, pages)
+			break
+		}
+	}
+	assert.Eq(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, ids)
+
+	page, err := query.PageAfter(lastId, 4)
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(page.Objects.([]*model.Entity)))
+	assert.True(t, !page.HasNext)
+	assert.Eq(t, lastId, page.LastId)
+
+	_, err = query.PageAfter(0, 0)
+	assert.Err(t, err)
+
+	// the page size is used instead of MaxResults
+	page, err = query.MaxResults(2).PageAfter(0, 4)
+	assert.NoErr(t, err)
+	assert.Eq(t, 4, len(page.Objects.([]*model.Entity)))
+	assert.True(t, page.HasNext)
+
+	_, err = query.PageAfterValue(nil, 0, 4)
+	assert.Err(t, err)
+}
+
+func TestQueryPageAfterValue(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(10)
+
+	// the parameter changed after creating the query applies to the pages as well
+	query := env.Box.Query(model.Entity_.Int64.GreaterThan(math.MinInt64).Alias("min"), model.Entity_.Int64.OrderDesc())
+	assert.NoErr(t, query.SetInt64Params(objectbox.Alias("min"), -1))
+
+	expectedObjects, err := query.Find()
+	assert.NoErr(t, err)
+	var expected []uint64
+	sort.SliceStable(expectedObjects, func(i, j int) bool {
+		if expectedObjects[i].Int64 != expectedObjects[j].Int64 {
+			return expectedObjects[i].Int64 > expectedObjects[j].Int64
+		}
+		return expectedObjects[i].Id < expectedObjects[j].Id
+	})
+	for _, object := range expectedObjects {
+		expected = append(expected, object.Id)
+	}
+	assert.True(t, len(expected) > 4)
+	assert.True(t, len(expected) < 10)
+
+	_, err = query.PageAfter(0, 3)
+	assert.Err(t, err)
+
+	var ids []uint64
+	var lastValue interface{}
+	var lastId uint64
+	for {
+		page, err := query.PageAfterValue(lastValue, lastId, 3)
+		assert.NoErr(t, err)
+
+		for _, object := range page.Objects.([]*model.Entity) {
+			ids = append(ids, object.Id)
+		}
+		lastValue, lastId = page.LastValue, page.LastId
+
+		if !page.HasNext {
+			break
+		}
+	}
+	assert.Eq(t, expected, ids)
+
+	// the cursor value can be given as the Go type of the property
+	page, err := query.PageAfterValue(expectedObjects[0].Int64, expectedObjects[0].Id, 1)
+	assert.NoErr(t, err)
+	assert.Eq(t, expected[1], page.Objects.([]*model.Entity)[0].Id)
+
+	_, err = query.PageAfterValue(nil, 1, 3)
+	assert.Err(t, err)
+
+	_, err = env.Box.Query(model.Entity_.Int64.OrderDesc(), model.Entity_.Int32.OrderAsc()).PageAfterValue(nil, 0, 3)
+	assert.Err(t, err)
+}
+
+func TestQueryIdConditions(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(10)
+
+	var findIds = func(conditions ...objectbox.Condition) []uint64 {
+		ids, err := env.Box.Query(conditions...).FindIds()
+		assert.NoErr(t, err)
+		return ids
+	}
+
+	assert.Eq(t, []uint64{3}, findIds(model.Entity_.Id.Equals(3)))
+	assert.Eq(t, []uint64{3, 4, 5}, findIds(model.Entity_.Id.Between(3, 5)))
+	assert.Eq(t, []uint64{2, 7}, findIds(model.Entity_.Id.In(7, 2, 42)))
+	assert.Eq(t, []uint64{9, 10}, findIds(model.Entity_.Id.GreaterThan(8)))
+
+	// incremental processing: reuse the query, changing the last seen ID
+	query := env.Box.Query(model.Entity_.Id.GreaterThan(0))
+	defer query.Close()
+
+	assert.NoErr(t, query.SetInt64Params(model.Entity_.Id, 10))
+	count, err := query.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), count)
+
+	env.PutEntity(model.Entity47())
+	ids, err := query.FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{11}, ids)
 }
 
 func TestQueryParams(t *testing.T) {
@@ -391,6 +726,11 @@ func TestQueryParams(t *testing.T) {
 		{5, s{`ByteVector < byte[5]{0x01020305 08}`}, box.Query(E.ByteVector.LessThan(nil)),
 			func(q i) error { return eq(q).SetBytesParams(E.ByteVector, e.ByteVector) }},
 
+		{256, s{`Bool == 1`}, box.Query(E.Bool.Equals(false)),
+			func(q i) error { return eq(q).SetBoolParams(E.Bool, true) }},
+		{744, s{`Bool == 0`}, box.Query(E.Bool.Equals(true)),
+			func(q i) error { return eq(q).SetBoolParams(E.Bool, false) }},
+
 		{1, s{`Related == 1`}, box.Query(E.Related.Equals(0)),
 			func(q i) error { return eq(q).SetInt64Params(E.Related, 1) }},
 		{1, s{`Related in [1]`}, box.Query(E.Related.In()),
@@ -819,6 +1159,58 @@ func TestQueryClose(t *testing.T) {
 	assert.NoErr(t, query.Close())
 }
 
+func TestQueryDescribe(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	query := env.Box.Query(model.Entity_.Int32.Equals(1), model.Entity_.String.HasPrefix("a", true))
+	desc, err := query.Describe()
+	assert.NoErr(t, err)
+	assert.True(t, strings.Contains(desc, "Entity"))
+
+	tree, err := query.DescribeTree()
+	assert.NoErr(t, err)
+	params, err := query.DescribeParams()
+	assert.NoErr(t, err)
+	assert.Eq(t, params, tree)
+
+	assert.NoErr(t, query.Close())
+	_, err = query.Describe()
+	assert.Err(t, err)
+	_, err = query.DescribeTree()
+	assert.Err(t, err)
+}
+
+func TestQueryClone(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(10)
+
+	query := env.Box.Query(model.Entity_.Id.GreaterThan(0)).Limit(3)
+	clone, err := query.Clone()
+	assert.NoErr(t, err)
+	defer clone.Close()
+
+	// the clone keeps the limit & parameters but changes don't propagate back to the original query
+	count, err := clone.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+
+	assert.NoErr(t, clone.SetInt64Params(model.Entity_.Id, 5))
+	objects, err := clone.Limit(10).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 5, len(objects.([]*model.Entity)))
+
+	found, err := query.Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 3, len(found))
+
+	// cloning a closed query fails
+	assert.NoErr(t, query.Close())
+	_, err = query.Clone()
+	assert.Err(t, err)
+}
+
 // Forces the finalizer to run; not a "real" test with assertions
 func TestQueryCloseFinalizer(t *testing.T) {
 	env := model.NewTestEnv(t)
@@ -911,6 +1303,73 @@ func TestQueryUint(t *testing.T) {
 	assert.Eq(t, uint64(1), count)
 }
 
+func TestQuerySmallIntegersIn(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	for i := 1; i <= 3; i++ {
+		env.PutEntity(&model.Entity{
+			Int8:   int8(-i),
+			Int16:  int16(-i * 1000),
+			Uint8:  uint8(200 + i),
+			Uint16: uint16(60000 + i),
+			Byte:   byte(i),
+		})
+	}
+
+	var count = func(condition objectbox.Condition) uint64 {
+		count, err := env.Box.Query(condition).Count()
+		assert.NoErr(t, err)
+		return count
+	}
+
+	assert.Eq(t, uint64(2), count(model.Entity_.Int8.In(-1, -3, 5)))
+	assert.Eq(t, uint64(1), count(model.Entity_.Int8.NotIn(-1, -3)))
+	assert.Eq(t, uint64(2), count(model.Entity_.Int16.In(-1000, -2000)))
+	assert.Eq(t, uint64(0), count(model.Entity_.Int16.NotIn(-1000, -2000, -3000)))
+	assert.Eq(t, uint64(1), count(model.Entity_.Uint8.In(203)))
+	assert.Eq(t, uint64(2), count(model.Entity_.Uint16.NotIn(60001)))
+	assert.Eq(t, uint64(3), count(model.Entity_.Byte.In(1, 2, 3)))
+
+	// range conditions for comparison
+	assert.Eq(t, uint64(2), count(model.Entity_.Int8.Between(-2, -1)))
+	assert.Eq(t, uint64(1), count(model.Entity_.Uint16.GreaterThan(60002)))
+
+	_, err := env.Box.Query(model.Entity_.Int8.In()).Count()
+	assert.Err(t, err)
+}
+
+func TestQueryFloatEquals(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	env.PutEntity(&model.Entity{Float32: 1.1, Float64: 1.1})
+	env.PutEntity(&model.Entity{Float32: 1.2, Float64: 1.2})
+
+	var count = func(condition objectbox.Condition) (uint64, error) {
+		return env.Box.Query(condition).Count()
+	}
+
+	c, err := count(model.Entity_.Float64.Equals(1.1, 0.01))
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), c)
+
+	c, err = count(model.Entity_.Float32.Equals(1.15, 0.1))
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), c)
+
+	// exact match
+	c, err = count(model.Entity_.Float64.Equals(1.2, 0))
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), c)
+
+	_, err = count(model.Entity_.Float64.Equals(1.1, -0.01))
+	assert.Err(t, err)
+
+	_, err = count(model.Entity_.Float32.Equals(1.1, -0.01))
+	assert.Err(t, err)
+}
+
 func TestQueryNil(t *testing.T) {
 	env := model.NewTestEnv(t)
 	defer env.Close()
@@ -1223,3 +1682,165 @@ func matchAllEntityIds(t *testing.T, ids []uint64, items interface{}) {
 
 	assert.EqItems(t, ids, actualIds)
 }
+
+func TestQueryRegexp(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	for i, text := range []string{"img_1", "img_22", "img_x", "IMG_3", "doc_4", ""} {
+		env.PutEntity(&model.Entity{String: text, Int32: int32(i)})
+	}
+
+	var find = func(pattern string, expectedPrefix string, conditions ...objectbox.Condition) []string {
+		query, err := env.Box.QueryRegexp(model.Entity_.String, pattern, conditions...)
+		assert.NoErr(t, err)
+		defer query.Close()
+		assert.Eq(t, expectedPrefix, query.Prefix())
+
+		objects, err := query.Find()
+		assert.NoErr(t, err)
+		var texts = []string{}
+		for _, object := range objects.([]*model.Entity) {
+			texts = append(texts, object.String)
+		}
+
+		count, err := query.Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, uint64(len(texts)), count)
+
+		ids, err := query.FindIds()
+		assert.NoErr(t, err)
+		assert.Eq(t, len(texts), len(ids))
+		return texts
+	}
+
+	assert.Eq(t, []string{"img_1", "img_22"}, find("^img_[0-9]+$", "img_"))
+	assert.Eq(t, []string{"img_1", "img_22", "IMG_3"}, find("(?i)^img_[0-9]+$", ""))
+	assert.Eq(t, []string{"img_1", "img_22", "IMG_3", "doc_4"}, find("_[0-9]", ""))
+	assert.Eq(t, []string{"img_22"}, find("^img_", "img_", model.Entity_.Int32.Equals(1)))
+	assert.Eq(t, []string{""}, find("^$", ""))
+	assert.Eq(t, []string{}, find("^pdf", "pdf"))
+
+	// streaming stops when the function returns false
+	query, err := env.Box.QueryRegexp(model.Entity_.String, "img")
+	assert.NoErr(t, err)
+	defer query.Close()
+	var visited int
+	assert.NoErr(t, query.Visit(func(object interface{}) bool {
+		visited++
+		return false
+	}))
+	assert.Eq(t, 1, visited)
+
+	// offset and limit apply to the matching objects
+	ranged, err := env.Box.QueryRegexp(model.Entity_.String, "_[0-9]")
+	assert.NoErr(t, err)
+	defer ranged.Close()
+	ranged.Query().Offset(1).Limit(2)
+	objects, err := ranged.Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(objects.([]*model.Entity)))
+	assert.Eq(t, "img_22", objects.([]*model.Entity)[0].String)
+	assert.Eq(t, "IMG_3", objects.([]*model.Entity)[1].String)
+	count, err := ranged.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	_, err = env.Box.QueryRegexp(model.Entity_.String, "(unclosed")
+	assert.Err(t, err)
+	_, err = env.Box.QueryRegexp(model.TestEntityRelated_.Name, "x")
+	assert.Err(t, err)
+}
+
+func TestQueryPipeline(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	for i := 1; i <= 10; i++ {
+		env.PutEntity(&model.Entity{Int32: int32(i), String: "e" + strconv.Itoa(i)})
+	}
+
+	var query = env.Box.Query(model.Entity_.Int32.GreaterThan(1))
+	var even = func(object interface{}) bool { return object.(*model.Entity).Int32%2 == 0 }
+	var name = func(object interface{}) interface{} { return object.(*model.Entity).String }
+
+	results, err := query.Filter(even).Map(name).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, []interface{}{"e2", "e4", "e6", "e8", "e10"}, results)
+
+	// filters after mapping see the mapped values
+	results, err = query.Map(name).Filter(func(value interface{}) bool { return len(value.(string)) == 3 }).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, []interface{}{"e10"}, results)
+
+	count, err := query.Filter(even).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(5), count)
+
+	// offset and limit apply to the filtered results
+	query.Offset(1).Limit(2)
+	results, err = query.Filter(even).Map(name).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, []interface{}{"e4", "e6"}, results)
+
+	// ... and are still applied natively to the query itself
+	ids, err := query.FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{3, 4}, ids)
+
+	// streaming stops when the function returns false
+	var visited int
+	assert.NoErr(t, query.Filter(even).Visit(func(result interface{}) bool {
+		visited++
+		return false
+	}))
+	assert.Eq(t, 1, visited)
+}
+
+func TestQueryFindSorted(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	for i := 1; i <= 10; i++ {
+		env.PutEntity(&model.Entity{Int32: int32(i), String: strings.Repeat("x", i%3)})
+	}
+
+	var byLength = func(a, b interface{}) bool {
+		return len(a.(*model.Entity).String) < len(b.(*model.Entity).String)
+	}
+	var byInt32Desc = func(a, b interface{}) bool { return a.(*model.Entity).Int32 > b.(*model.Entity).Int32 }
+	var int32s = func(objects interface{}) []int32 {
+		var result = []int32{}
+		for _, object := range objects.([]*model.Entity) {
+			result = append(result, object.Int32)
+		}
+		return result
+	}
+
+	var query = env.Box.Query()
+
+	// equal objects keep the query order
+	objects, err := query.FindSorted(byLength)
+	assert.NoErr(t, err)
+	assert.Eq(t, []int32{3, 6, 9, 1, 4, 7, 10, 2, 5, 8}, int32s(objects))
+
+	objects, err = query.FindSorted(objectbox.LessBy(byLength, byInt32Desc))
+	assert.NoErr(t, err)
+	assert.Eq(t, []int32{9, 6, 3, 10, 7, 4, 1, 8, 5, 2}, int32s(objects))
+
+	// top-N with a bounded heap, offset and limit apply to the sorted result
+	query.Offset(2).Limit(3)
+	objects, err = query.FindSorted(objectbox.LessBy(byLength, byInt32Desc))
+	assert.NoErr(t, err)
+	assert.Eq(t, []int32{3, 10, 7}, int32s(objects))
+
+	query.Offset(0).Limit(4)
+	objects, err = query.FindSorted(byLength)
+	assert.NoErr(t, err)
+	assert.Eq(t, []int32{3, 6, 9, 1}, int32s(objects))
+
+	query.Offset(20).Limit(0)
+	objects, err = query.FindSorted(byLength)
+	assert.NoErr(t, err)
+	assert.Eq(t, []int32{}, int32s(objects))
+}
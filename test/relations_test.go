@@ -19,6 +19,7 @@ package objectbox_test
 import (
 	"testing"
 
+	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/objectbox/objectbox-go/test/assert"
 	"github.com/objectbox/objectbox-go/test/model"
 )
@@ -259,3 +260,165 @@ func TestRelationsMissing(t *testing.T) {
 	assert.True(t, 0 == len(read.RelatedSlice))
 	assert.True(t, nil == read.RelatedPtrSlice)
 }
+
+func TestRelationsCount(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	for i := 0; i < 4; i++ {
+		var object = &model.Entity{
+			Int32:           int32(i),
+			Related:         model.TestEntityRelated{NextSlice: []model.EntityByValue{}},
+			RelatedSlice:    make([]model.EntityByValue, i),
+			RelatedPtrSlice: []*model.TestEntityRelated{},
+		}
+		_, err := env.Box.Put(object)
+		assert.NoErr(t, err)
+	}
+
+	var find = func(conditions ...objectbox.Condition) []uint64 {
+		ids, err := env.Box.Query(conditions...).FindIds()
+		assert.NoErr(t, err)
+		return ids
+	}
+
+	assert.Eq(t, []uint64{3, 4}, find(model.Entity_.RelatedSlice.CountGreaterThan(1)))
+	assert.Eq(t, []uint64{1, 2}, find(model.Entity_.RelatedSlice.CountLessThan(2)))
+	assert.Eq(t, []uint64{1}, find(model.Entity_.RelatedSlice.CountEquals(0)))
+	assert.Eq(t, []uint64{2, 3}, find(model.Entity_.RelatedSlice.CountBetween(1, 2)))
+	assert.Eq(t, []uint64{4}, find(model.Entity_.RelatedSlice.CountGreaterThan(1), model.Entity_.Int32.Equals(3)))
+	assert.Eq(t, 0, len(find(model.Entity_.RelatedSlice.CountGreaterThan(3))))
+	assert.Eq(t, 0, len(find(model.Entity_.RelatedPtrSlice.CountGreaterThan(0))))
+
+	// the matching objects are determined when the query is built, a new query is necessary to see changes
+	var query = env.Box.Query(model.Entity_.RelatedSlice.CountGreaterThan(2))
+	defer query.Close()
+	ids, err := query.FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{4}, ids)
+
+	object, err := env.Box.Get(3)
+	assert.NoErr(t, err)
+	object.RelatedSlice = append(object.RelatedSlice, model.EntityByValue{})
+	_, err = env.Box.Put(object)
+	assert.NoErr(t, err)
+
+	ids, err = query.FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{4}, ids)
+	assert.Eq(t, []uint64{3, 4}, find(model.Entity_.RelatedSlice.CountGreaterThan(2)))
+}
+
+func TestRelationsBacklinks(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var relBox = model.BoxForTestEntityRelated(env.ObjectBox)
+	var target = &model.TestEntityRelated{Name: "target", NextSlice: []model.EntityByValue{}}
+	var other = &model.TestEntityRelated{Name: "other", NextSlice: []model.EntityByValue{}}
+	_, err := relBox.PutMany([]*model.TestEntityRelated{target, other})
+	assert.NoErr(t, err)
+
+	for _, rel := range []*model.TestEntityRelated{target, other, target} {
+		_, err = env.Box.Put(&model.Entity{
+			Related:         model.TestEntityRelated{NextSlice: []model.EntityByValue{}},
+			RelatedPtr:      rel,
+			RelatedPtrSlice: []*model.TestEntityRelated{},
+		})
+		assert.NoErr(t, err)
+	}
+
+	ids, err := env.Box.BacklinkIds(model.Entity_.RelatedPtr, target.Id)
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{1, 3}, ids)
+
+	objects, err := env.Box.GetBacklinks(model.Entity_.RelatedPtr, other.Id)
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(objects.([]*model.Entity)))
+	assert.Eq(t, uint64(2), objects.([]*model.Entity)[0].Id)
+
+	ids, err = env.Box.BacklinkIds(model.Entity_.RelatedPtr2, target.Id)
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(ids))
+
+	_, err = env.Box.BacklinkIds(model.TestEntityRelated_.Next, target.Id)
+	assert.Err(t, err)
+}
+
+func TestRelationsLinkBox(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	// Entity serves as the link entity, connecting RelatedPtr (source) to RelatedPtr2 (target), with String as a role
+	var relBox = model.BoxForTestEntityRelated(env.ObjectBox)
+	var a = &model.TestEntityRelated{Name: "a", NextSlice: []model.EntityByValue{}}
+	var b = &model.TestEntityRelated{Name: "b", NextSlice: []model.EntityByValue{}}
+	var c = &model.TestEntityRelated{Name: "c", NextSlice: []model.EntityByValue{}}
+	_, err := relBox.PutMany([]*model.TestEntityRelated{a, b, c})
+	assert.NoErr(t, err)
+
+	links, err := env.ObjectBox.LinkBox(model.Entity_.RelatedPtr, model.Entity_.RelatedPtr2)
+	assert.NoErr(t, err)
+
+	var link = func(source, target *model.TestEntityRelated, role string) *model.Entity {
+		return &model.Entity{
+			Related:         model.TestEntityRelated{NextSlice: []model.EntityByValue{}},
+			RelatedPtr:      source,
+			RelatedPtr2:     target,
+			RelatedPtrSlice: []*model.TestEntityRelated{},
+			String:          role,
+		}
+	}
+
+	_, err = links.PutLink(link(a, b, "member"))
+	assert.NoErr(t, err)
+	_, err = links.PutLink(link(a, c, "admin"))
+	assert.NoErr(t, err)
+	_, err = links.PutLink(link(b, c, "member"))
+	assert.NoErr(t, err)
+
+	// an existing link between the same objects is updated
+	var updated = link(a, b, "owner")
+	id, err := links.PutLink(updated)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), id)
+	assert.Eq(t, uint64(1), updated.Id)
+
+	count, err := links.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+
+	found, err := links.GetLink(a.Id, b.Id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "owner", found.(*model.Entity).String)
+
+	ids, err := links.TargetIds(a.Id)
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{b.Id, c.Id}, ids)
+
+	ids, err = links.SourceIds(c.Id, model.Entity_.String.Equals("member", true))
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{b.Id}, ids)
+
+	objects, err := links.FindByTarget(c.Id)
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(objects.([]*model.Entity)))
+
+	removed, err := links.RemoveLink(a.Id, c.Id)
+	assert.NoErr(t, err)
+	assert.True(t, removed)
+	removed, err = links.RemoveLink(a.Id, c.Id)
+	assert.NoErr(t, err)
+	assert.True(t, !removed)
+
+	found, err = links.GetLink(a.Id, c.Id)
+	assert.NoErr(t, err)
+	assert.True(t, found == nil)
+
+	// related objects must be put before
+	_, err = links.PutLink(link(a, &model.TestEntityRelated{Name: "new"}, "member"))
+	assert.Err(t, err)
+
+	_, err = env.ObjectBox.LinkBox(model.Entity_.RelatedPtr, model.TestEntityRelated_.Next)
+	assert.Err(t, err)
+}
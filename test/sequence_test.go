@@ -0,0 +1,88 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestSequence(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	_, err := env.ObjectBox.NewSequence(nil, nil)
+	assert.Err(t, err)
+	_, err = env.ObjectBox.NewSequence(iot.Reading_.ValueName, iot.Event_.Date)
+	assert.Err(t, err)
+
+	// the Reading box serves as the sequence in this test
+	sequence, err := env.ObjectBox.NewSequence(iot.Reading_.ValueName, iot.Reading_.ValueInteger)
+	assert.NoErr(t, err)
+
+	var next = func(name string) int64 {
+		value, err := sequence.NextValue(name)
+		assert.NoErr(t, err)
+		return value
+	}
+
+	assert.Eq(t, int64(1), next("invoices"))
+	assert.Eq(t, int64(2), next("invoices"))
+	assert.Eq(t, int64(1), next("orders"))
+
+	value, err := sequence.Value("invoices")
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(2), value)
+
+	value, err = sequence.Value("unknown")
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(0), value)
+
+	// the value is only used up if the transaction is committed
+	var expected = errors.New("expected")
+	assert.Eq(t, expected, env.ObjectBox.RunInWriteTx(func() error {
+		assert.Eq(t, int64(3), next("invoices"))
+		return expected
+	}))
+	assert.Eq(t, int64(3), next("invoices"))
+
+	// concurrent increments never return the same value
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var values = make(map[int64]bool)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var value = next("concurrent")
+			mutex.Lock()
+			values[value] = true
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Eq(t, 10, len(values))
+
+	// a single object per counter
+	count, err := iot.BoxForReading(env.ObjectBox).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestSoftDelete(t *testing.T) {
+	ob, cleanup := newSoftDeleteStore(t)
+	defer cleanup()
+
+	var box = iot.BoxForEvent(ob)
+	var a = iot.PutEvent(ob, "a", 0)
+	var b = iot.PutEvent(ob, "b", 0)
+	var c = iot.PutEvent(ob, "c", 0)
+
+	var countQuery = func() uint64 {
+		count, err := box.Query().Count()
+		assert.NoErr(t, err)
+		return count
+	}
+
+	assert.NoErr(t, box.RemoveId(a.Id))
+	assert.Err(t, box.RemoveId(a.Id)) // already deleted
+	assert.Eq(t, uint64(2), countQuery())
+
+	// the object is still stored, with the deletion time set
+	event, err := box.Get(a.Id)
+	assert.NoErr(t, err)
+	assert.True(t, event.Date > 0)
+
+	query, err := box.QueryIncludingDeleted()
+	assert.NoErr(t, err)
+	count, err := query.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+
+	// query remove is soft as well
+	removed, err := box.Query(iot.Event_.Device.Equals("b", true)).Remove()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), removed)
+	assert.Eq(t, uint64(1), countQuery())
+
+	found, err := box.RestoreId(b.Id)
+	assert.NoErr(t, err)
+	assert.True(t, found)
+	assert.Eq(t, uint64(2), countQuery())
+
+	// purge the objects deleted until now, i.e. only "a"
+	purged, err := box.PurgeDeletedBefore(time.Now().Add(time.Millisecond))
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), purged)
+	assert.Eq(t, uint64(2), storedCount(t, box))
+
+	removed, err = box.RemoveHard(c.Id)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), removed)
+	assert.Eq(t, uint64(1), storedCount(t, box))
+
+	// other entities aren't affected
+	_, err = iot.BoxForReading(ob).RestoreId(1)
+	assert.Err(t, err)
+}
+
+func TestSoftDeleteBuilder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).SoftDelete(nil).BuildOrError()
+	assert.Err(t, err)
+
+	_, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).
+		SoftDelete(iot.Event_.Date).SoftDelete(iot.Event_.Date).BuildOrError()
+	assert.Err(t, err)
+}
+
+func TestSoftDeleteExpiration(t *testing.T) {
+	ob, cleanup := newSoftDeleteStore(t)
+	defer cleanup()
+
+	// deleted objects are marked with the current time, i.e. they expire right away and get removed for good
+	var box = iot.BoxForEvent(ob)
+	var a = iot.PutEvent(ob, "a", 0)
+	iot.PutEvent(ob, "b", 0)
+	assert.NoErr(t, box.RemoveId(a.Id))
+
+	worker, err := ob.StartExpirationWorker(time.Hour, objectbox.ExpirationRule{Property: iot.Event_.Date})
+	assert.NoErr(t, err)
+	defer worker.Stop()
+
+	assert.NoErr(t, worker.RunOnce())
+	assert.Eq(t, map[string]uint64{"Event": 1}, worker.Purged())
+	assert.Eq(t, uint64(1), storedCount(t, box))
+}
+
+// newSoftDeleteStore opens a store in a new temporary directory, with Event.Date serving as the "deleted at" property.
+// Call the returned function to close the store and remove the directory.
+func newSoftDeleteStore(t *testing.T) (*objectbox.ObjectBox, func()) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).SoftDelete(iot.Event_.Date).BuildOrError()
+	assert.NoErr(t, err)
+	return ob, func() {
+		ob.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// storedCount returns the number of all stored objects, including those marked as deleted
+func storedCount(t *testing.T, box *iot.EventBox) uint64 {
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	return count
+}
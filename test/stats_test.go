@@ -0,0 +1,41 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"testing"
+
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestStats(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	iot.PutEvents(env.ObjectBox, 3)
+
+	stats, err := env.ObjectBox.Stats()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), stats.EntityCounts["Event"])
+	assert.Eq(t, uint64(0), stats.EntityCounts["Reading"])
+	assert.True(t, stats.SizeOnDisk > 0)
+
+	size, err := env.ObjectBox.SizeOnDisk()
+	assert.NoErr(t, err)
+	assert.Eq(t, stats.SizeOnDisk, size)
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestStoreManager(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	manager, err := objectbox.NewStoreManager(func(key string) (*objectbox.ObjectBox, error) {
+		return objectbox.NewBuilder().Directory(filepath.Join(dir, key)).Model(iot.ObjectBoxModel()).BuildOrError()
+	}, 2, 0)
+	assert.NoErr(t, err)
+	defer manager.Close()
+
+	var count = func(key string) uint64 {
+		var result uint64
+		assert.NoErr(t, manager.Run(key, func(ob *objectbox.ObjectBox) error {
+			var err error
+			result, err = iot.BoxForEvent(ob).Count()
+			return err
+		}))
+		return result
+	}
+
+	// each store has its own data
+	a, err := manager.Acquire("a")
+	assert.NoErr(t, err)
+	iot.PutEvents(a, 3)
+
+	b, err := manager.Acquire("b")
+	assert.NoErr(t, err)
+	iot.PutEvents(b, 5)
+	assert.Eq(t, 2, manager.OpenStores())
+
+	// both stores are in use so a third one can't be opened
+	_, err = manager.Acquire("c")
+	assert.Err(t, err)
+
+	// after releasing, "a" is the least recently used store and gets closed to open "c"
+	manager.Release("a")
+	manager.Release("b")
+	assert.Eq(t, uint64(0), count("c"))
+	assert.Eq(t, 2, manager.OpenStores())
+
+	// reopening "a" closes "b"; the data is still there
+	assert.Eq(t, uint64(3), count("a"))
+	assert.Eq(t, uint64(5), count("b"))
+	assert.Eq(t, 2, manager.OpenStores())
+
+	manager.Close()
+	assert.Eq(t, 0, manager.OpenStores())
+	_, err = manager.Acquire("a")
+	assert.Err(t, err)
+}
+
+func TestStoreManagerIdle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	manager, err := objectbox.NewStoreManager(func(key string) (*objectbox.ObjectBox, error) {
+		return objectbox.NewBuilder().Directory(filepath.Join(dir, key)).Model(iot.ObjectBoxModel()).BuildOrError()
+	}, 0, 20*time.Millisecond)
+	assert.NoErr(t, err)
+	defer manager.Close()
+
+	_, err = manager.Acquire("used")
+	assert.NoErr(t, err)
+	assert.NoErr(t, manager.Run("idle", func(ob *objectbox.ObjectBox) error { return nil }))
+	assert.Eq(t, 2, manager.OpenStores())
+
+	// only the released store is closed
+	time.Sleep(100 * time.Millisecond)
+	assert.Eq(t, 1, manager.OpenStores())
+	manager.Release("used")
+}
+
+func TestStoreManagerConcurrentOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var unblock = make(chan struct{})
+	var opens int32
+	manager, err := objectbox.NewStoreManager(func(key string) (*objectbox.ObjectBox, error) {
+		atomic.AddInt32(&opens, 1)
+		if key == "slow" {
+			<-unblock
+		}
+		return objectbox.NewBuilder().Directory(filepath.Join(dir, key)).Model(iot.ObjectBoxModel()).BuildOrError()
+	}, 0, 0)
+	assert.NoErr(t, err)
+	defer manager.Close()
+
+	// concurrent calls for the same store wait for a single open
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoErr(t, manager.Run("slow", func(ob *objectbox.ObjectBox) error { return nil }))
+		}()
+	}
+
+	// other stores can be used while "slow" is being opened
+	time.Sleep(20 * time.Millisecond)
+	assert.NoErr(t, manager.Run("fast", func(ob *objectbox.ObjectBox) error { return nil }))
+
+	close(unblock)
+	wg.Wait()
+	assert.Eq(t, int32(2), atomic.LoadInt32(&opens))
+	assert.Eq(t, 2, manager.OpenStores())
+}
+
+func TestStoreManagerShortIdleTimeout(t *testing.T) {
+	manager, err := objectbox.NewStoreManager(func(key string) (*objectbox.ObjectBox, error) {
+		return nil, errors.New("not used")
+	}, 0, time.Nanosecond)
+	assert.NoErr(t, err)
+	manager.Close()
+}
+
+func TestBuilderModelReuse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var model = iot.ObjectBoxModel()
+	ob, err := objectbox.NewBuilder().Directory(filepath.Join(dir, "a")).Model(model).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	_, err = objectbox.NewBuilder().Directory(filepath.Join(dir, "b")).Model(model).BuildOrError()
+	assert.Err(t, err)
+}
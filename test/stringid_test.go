@@ -113,3 +113,32 @@ func TestSelfAssignedId(t *testing.T) {
 	assert.Eq(t, uint64(1), ids[0])
 	assert.Eq(t, uint64(10), ids[1])
 }
+
+func TestSelfAssignedIdDuplicates(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	box := model.BoxForTestStringIdEntity(env.ObjectBox)
+
+	// imported IDs may be above the last assigned ID as well as below it
+	_, err := box.Insert(&model.TestStringIdEntity{Id: "1000"})
+	assert.NoErr(t, err)
+	_, err = box.Insert(&model.TestStringIdEntity{Id: "5"})
+	assert.NoErr(t, err)
+
+	// an ID that is already in use is detected
+	id, err := box.Insert(&model.TestStringIdEntity{Id: "1000"})
+	assert.Err(t, err)
+	assert.Eq(t, uint64(0), id)
+
+	// new objects continue after the highest ID
+	var object = &model.TestStringIdEntity{}
+	id, err = box.Put(object)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1001), id)
+	assert.Eq(t, "1001", object.Id)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+}
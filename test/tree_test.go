@@ -0,0 +1,175 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+// treeNode is a self-referencing entity; none of the generated test models has one so the binding is written by hand
+type treeNode struct {
+	Id       uint64
+	Name     string
+	ParentId uint64
+}
+
+type treeNode_EntityInfo struct {
+	objectbox.Entity
+}
+
+var treeNodeBinding = treeNode_EntityInfo{Entity: objectbox.Entity{Id: 1}}
+
+var treeNodeParent = &objectbox.RelationToOne{
+	Property: &objectbox.BaseProperty{Id: 3, Entity: &treeNodeBinding.Entity},
+	Target:   &treeNodeBinding.Entity,
+}
+
+func (treeNode_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+func (treeNode_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("TreeNode", 1, 7153170296345315101)
+	model.Property("Id", 6, 1, 6187321480624416102)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 2816362829435829318)
+	model.Property("ParentId", 11, 3, 5264410398720416425)
+	model.PropertyFlags(520)
+	model.PropertyRelation("TreeNode", 1, 1489063240245066233)
+	model.EntityLastPropertyId(3, 5264410398720416425)
+}
+
+func (treeNode_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*treeNode).Id, nil
+}
+
+func (treeNode_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*treeNode).Id = id
+	return nil
+}
+
+func (treeNode_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+func (treeNode_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*treeNode)
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+
+	fbb.StartObject(3)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetUint64Slot(fbb, 2, obj.ParentId)
+	return nil
+}
+
+func (treeNode_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	return &treeNode{
+		Id:       fbutils.GetUint64Slot(table, 4),
+		Name:     fbutils.GetStringSlot(table, 6),
+		ParentId: fbutils.GetUint64Slot(table, 8),
+	}, nil
+}
+
+func (treeNode_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*treeNode, 0, capacity)
+}
+
+func (treeNode_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	return append(slice.([]*treeNode), object.(*treeNode))
+}
+
+func treeNodeNames(t *testing.T) func(slice interface{}, err error) []string {
+	return func(slice interface{}, err error) []string {
+		assert.NoErr(t, err)
+		var names = []string{}
+		for _, node := range slice.([]*treeNode) {
+			names = append(names, node.Name)
+		}
+		return names
+	}
+}
+
+func TestTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var model = objectbox.NewModel()
+	model.GeneratorVersion(6)
+	model.RegisterBinding(treeNodeBinding)
+	model.LastEntityId(1, 7153170296345315101)
+	model.LastIndexId(1, 1489063240245066233)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(model).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	var box = ob.InternalBox(1)
+
+	// root -> a -> a1 -> a1x
+	//      -> b
+	var put = func(name string, parentId uint64) uint64 {
+		id, err := box.Put(&treeNode{Name: name, ParentId: parentId})
+		assert.NoErr(t, err)
+		return id
+	}
+	var root = put("root", 0)
+	var a = put("a", root)
+	put("b", root)
+	var a1 = put("a1", a)
+	var a1x = put("a1x", a1)
+
+	assert.Eq(t, []string{"a", "b"}, treeNodeNames(t)(box.GetChildren(treeNodeParent, root)))
+	assert.Eq(t, []string{}, treeNodeNames(t)(box.GetChildren(treeNodeParent, a1x)))
+
+	assert.Eq(t, []string{"a", "b", "a1", "a1x"}, treeNodeNames(t)(box.GetSubtree(treeNodeParent, root, 0)))
+	assert.Eq(t, []string{"a", "b", "a1"}, treeNodeNames(t)(box.GetSubtree(treeNodeParent, root, 2)))
+	assert.Eq(t, []string{"a1", "a1x"}, treeNodeNames(t)(box.GetSubtree(treeNodeParent, a, 0)))
+
+	assert.Eq(t, []string{"a1", "a", "root"}, treeNodeNames(t)(box.GetAncestors(treeNodeParent, a1x)))
+	assert.Eq(t, []string{}, treeNodeNames(t)(box.GetAncestors(treeNodeParent, root)))
+
+	// make root a child of a1x to create a cycle
+	_, err = box.Put(&treeNode{Id: root, Name: "root", ParentId: a1x})
+	assert.NoErr(t, err)
+
+	_, err = box.GetAncestors(treeNodeParent, a1)
+	assert.Err(t, err)
+
+	_, err = box.GetSubtree(treeNodeParent, a, 0)
+	assert.Err(t, err)
+
+	// relations between different entities are rejected
+	env := iot.NewTestEnv()
+	defer env.Close()
+	_, err = env.ObjectBox.InternalBox(2).GetChildren(iot.Reading_.EventId, 1)
+	assert.Err(t, err)
+}
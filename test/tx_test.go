@@ -18,8 +18,12 @@ package objectbox_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 
+	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/objectbox/objectbox-go/test/assert"
 	"github.com/objectbox/objectbox-go/test/model/iot"
 )
@@ -93,3 +97,309 @@ func TestTransactionRollback(t *testing.T) {
 	assert.Eq(t, 0, int(count))
 
 }
+
+func TestCloseWaitsForTransaction(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var ob = env.ObjectBox
+	var box = iot.BoxForEvent(ob)
+	var query = box.Query()
+	defer query.Close()
+
+	var started = make(chan struct{})
+	var finish = make(chan struct{})
+	var finished = make(chan error)
+	go func() {
+		finished <- ob.RunInWriteTx(func() error {
+			close(started)
+			<-finish
+
+			// operations inside the transaction proceed even though the store is closing
+			_, err := box.Put(&iot.Event{})
+			return err
+		})
+	}()
+	<-started
+
+	// the transaction is still running
+	assert.Err(t, ob.CloseWithTimeout(20*time.Millisecond))
+
+	// new operations are rejected while closing
+	_, err := box.Count()
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+
+	close(finish)
+	ob.Close()
+	assert.NoErr(t, <-finished)
+
+	_, err = box.Put(&iot.Event{})
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+
+	_, err = query.Find()
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+
+	assert.Eq(t, objectbox.ErrStoreClosed, ob.RunInReadTx(func() error { return nil }))
+}
+
+func TestView(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	iot.PutEvents(env.ObjectBox, 10)
+
+	assert.NoErr(t, env.View(func(tx *objectbox.ReadTx) error {
+		var box = iot.BoxForEvent(tx.ObjectBox())
+
+		countBefore, err := box.Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, uint64(10), countBefore)
+
+		// a write committed in another goroutine isn't visible inside the view
+		var done = make(chan error)
+		go func() {
+			_, err := iot.BoxForEvent(env.ObjectBox).Put(&iot.Event{})
+			done <- err
+		}()
+		assert.NoErr(t, <-done)
+
+		count, err := box.Query(iot.Event_.Id.GreaterThan(0)).Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, countBefore, count)
+
+		readings, err := tx.Box(iot.ReadingBinding.Id)
+		assert.NoErr(t, err)
+		count, err = readings.Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, uint64(0), count)
+		return nil
+	}))
+
+	count, err := iot.BoxForEvent(env.ObjectBox).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(11), count)
+
+	var expected = errors.New("expected")
+	assert.Eq(t, expected, env.View(func(tx *objectbox.ReadTx) error {
+		return expected
+	}))
+}
+
+func TestWriteQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).
+		WriteQueue(1, 100*time.Millisecond).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	var box = iot.BoxForEvent(ob)
+
+	// writes nested in a write transaction already have their turn
+	assert.NoErr(t, ob.RunInWriteTx(func() error {
+		_, err := box.Put(&iot.Event{})
+		return err
+	}))
+
+	var started = make(chan struct{})
+	var finish = make(chan struct{})
+	var finished = make(chan error)
+	go func() {
+		finished <- ob.RunInWriteTx(func() error {
+			close(started)
+			<-finish
+			return nil
+		})
+	}()
+	<-started
+
+	var putAsync = func() chan error {
+		var result = make(chan error)
+		go func() {
+			_, err := box.Put(&iot.Event{})
+			result <- err
+		}()
+		for ob.WriteQueueStats().Waiting == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		return result
+	}
+
+	// the first writer waits, the second one is rejected as the queue is full
+	var waiting = putAsync()
+	_, err = box.Put(&iot.Event{})
+	assert.Eq(t, objectbox.ErrWriteQueueFull, err)
+	assert.Eq(t, objectbox.ErrWriteQueueTimeout, <-waiting)
+
+	// a waiting writer gets its turn after the transaction finishes
+	waiting = putAsync()
+	close(finish)
+	assert.NoErr(t, <-finished)
+	assert.NoErr(t, <-waiting)
+
+	var stats = ob.WriteQueueStats()
+	assert.Eq(t, 0, stats.Waiting)
+	assert.Eq(t, 1, stats.MaxWaiting)
+	assert.Eq(t, uint64(3), stats.Acquired)
+	assert.Eq(t, uint64(1), stats.Rejected)
+	assert.Eq(t, uint64(1), stats.TimedOut)
+	assert.True(t, stats.WaitTime > 0)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	// a write to another store nested in a write transaction waits for the turn of that store's queue
+	dirOther, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dirOther)
+
+	other, err := objectbox.NewBuilder().Directory(dirOther).Model(iot.ObjectBoxModel()).
+		WriteQueue(1, 100*time.Millisecond).BuildOrError()
+	assert.NoErr(t, err)
+	defer other.Close()
+
+	started = make(chan struct{})
+	finish = make(chan struct{})
+	go func() {
+		finished <- other.RunInWriteTx(func() error {
+			close(started)
+			<-finish
+			return nil
+		})
+	}()
+	<-started
+
+	assert.NoErr(t, ob.RunInWriteTx(func() error {
+		_, err := iot.BoxForEvent(other).Put(&iot.Event{})
+		assert.Eq(t, objectbox.ErrWriteQueueTimeout, err)
+
+		// the turn of the outer store's queue is still held
+		_, err = box.Put(&iot.Event{})
+		return err
+	}))
+	close(finish)
+	assert.NoErr(t, <-finished)
+	assert.Eq(t, uint64(1), other.WriteQueueStats().TimedOut)
+}
+
+func TestTxListener(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var box = iot.BoxForEvent(env.ObjectBox)
+
+	var commits, rollbacks int
+	var lastErr error
+	var countOnCommit uint64
+	var unregister = env.ObjectBox.RegisterTxListener(objectbox.TxListener{
+		OnCommit: func() {
+			commits++
+			// committed data is visible and listeners may start new transactions
+			var err error
+			countOnCommit, err = box.Count()
+			assert.NoErr(t, err)
+		},
+		OnRollback: func(err error) {
+			rollbacks++
+			lastErr = err
+		},
+	})
+
+	// a single notification for a transaction including nested ones
+	var afterCommit, afterRollback int
+	assert.NoErr(t, env.RunInWriteTx(func() error {
+		assert.NoErr(t, env.ObjectBox.AfterCommit(func() { afterCommit++ }))
+		assert.NoErr(t, env.ObjectBox.AfterRollback(func(error) { afterRollback++ }))
+		return env.RunInWriteTx(func() error {
+			assert.NoErr(t, env.ObjectBox.AfterCommit(func() { afterCommit++ }))
+			_, err := box.Put(&iot.Event{})
+			return err
+		})
+	}))
+	assert.Eq(t, 1, commits)
+	assert.Eq(t, 0, rollbacks)
+	assert.Eq(t, 2, afterCommit)
+	assert.Eq(t, 0, afterRollback)
+	assert.Eq(t, uint64(1), countOnCommit)
+
+	// rolled back
+	var expected = errors.New("expected")
+	assert.Eq(t, expected, env.RunInWriteTx(func() error {
+		assert.NoErr(t, env.ObjectBox.AfterCommit(func() { afterCommit++ }))
+		assert.NoErr(t, env.ObjectBox.AfterRollback(func(err error) {
+			assert.Eq(t, expected, err)
+			afterRollback++
+		}))
+		_, err := box.Put(&iot.Event{})
+		assert.NoErr(t, err)
+		return expected
+	}))
+	assert.Eq(t, 1, commits)
+	assert.Eq(t, 1, rollbacks)
+	assert.Eq(t, expected, lastErr)
+	assert.Eq(t, 2, afterCommit)
+	assert.Eq(t, 1, afterRollback)
+
+	// read transactions and writes outside of an explicit transaction aren't reported
+	assert.NoErr(t, env.RunInReadTx(func() error { return nil }))
+	_, err := box.Put(&iot.Event{})
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, commits)
+
+	assert.Err(t, env.ObjectBox.AfterCommit(func() {}))
+	assert.Err(t, env.ObjectBox.AfterRollback(func(error) {}))
+	assert.Err(t, env.RunInReadTx(func() error { return env.ObjectBox.AfterCommit(func() {}) }))
+
+	unregister()
+	assert.NoErr(t, env.RunInWriteTx(func() error { return nil }))
+	assert.Eq(t, 1, commits)
+}
+
+func TestTxListenerTwoStores(t *testing.T) {
+	envA := iot.NewTestEnv()
+	defer envA.Close()
+	envB := iot.NewTestEnv()
+	defer envB.Close()
+
+	// a transaction of store B running in another goroutine
+	var started = make(chan struct{})
+	var finish = make(chan struct{})
+	var finished = make(chan error)
+	go func() {
+		finished <- envB.RunInWriteTx(func() error {
+			close(started)
+			<-finish
+			return nil
+		})
+	}()
+	<-started
+
+	// isn't mistaken for the current transaction in a transaction of store A
+	assert.NoErr(t, envA.RunInWriteTx(func() error {
+		assert.Err(t, envB.ObjectBox.AfterCommit(func() {}))
+		return envA.ObjectBox.AfterCommit(func() {})
+	}))
+	close(finish)
+	assert.NoErr(t, <-finished)
+
+	// a transaction of store B nested in a transaction of store A is a top-level one for store B
+	var commitsA, commitsB, afterCommitB int
+	envA.ObjectBox.RegisterTxListener(objectbox.TxListener{OnCommit: func() { commitsA++ }})
+	envB.ObjectBox.RegisterTxListener(objectbox.TxListener{OnCommit: func() { commitsB++ }})
+	assert.NoErr(t, envA.RunInWriteTx(func() error {
+		assert.NoErr(t, envB.RunInWriteTx(func() error {
+			return envB.ObjectBox.AfterCommit(func() { afterCommitB++ })
+		}))
+		assert.Eq(t, 1, commitsB)
+
+		// the transaction of store A is current again
+		assert.Err(t, envB.ObjectBox.AfterCommit(func() {}))
+		return envA.ObjectBox.AfterCommit(func() {})
+	}))
+	assert.Eq(t, 1, commitsA)
+	assert.Eq(t, 1, commitsB)
+	assert.Eq(t, 1, afterCommitB)
+}
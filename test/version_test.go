@@ -17,12 +17,15 @@
 package objectbox_test
 
 import (
+	"io/ioutil"
+	"os"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
 )
 
 func TestObjectBoxVersionString(t *testing.T) {
@@ -60,6 +63,7 @@ func TestExpectedObjectBoxVersion(t *testing.T) {
 }
 
 func TestObjectBoxMinLibVersion(t *testing.T) {
+	assert.NoErr(t, objectbox.CheckLibVersion())
 	assert.True(t, objectbox.VersionLib().GreaterThanOrEqualTo(objectbox.VersionLibMin()))
 	assert.True(t, objectbox.VersionLibMinRecommended().GreaterThanOrEqualTo(objectbox.VersionLibMin()))
 	assert.True(t, objectbox.VersionLibStatic().GreaterThanOrEqualTo(objectbox.VersionLibMinRecommended()))
@@ -86,6 +90,10 @@ func TestVersion(t *testing.T) {
 	assert.True(t, objectbox.Version{Major: 0, Minor: 1, Patch: 0}.LessThan(objectbox.Version{Major: 0, Minor: 1, Patch: 1}))
 	assert.True(t, objectbox.Version{Major: 1, Minor: 1, Patch: 0}.LessThan(objectbox.Version{Major: 1, Minor: 1, Patch: 1}))
 	assert.True(t, objectbox.Version{Major: 1, Minor: 0, Patch: 1}.LessThan(objectbox.Version{Major: 1, Minor: 1, Patch: 1}))
+
+	assert.True(t, !objectbox.Version{Major: 1, Minor: 0, Patch: 0}.LessThan(objectbox.Version{Major: 0, Minor: 18, Patch: 0}))
+	assert.True(t, !objectbox.Version{Major: 0, Minor: 18, Patch: 0}.LessThan(objectbox.Version{Major: 0, Minor: 15, Patch: 1}))
+	assert.True(t, !objectbox.Version{Major: 1, Minor: 1, Patch: 1}.LessThan(objectbox.Version{Major: 1, Minor: 1, Patch: 1}))
 }
 
 func TestVersionLabel(t *testing.T) {
@@ -94,3 +102,45 @@ func TestVersionLabel(t *testing.T) {
 	version.Label = ""
 	assert.Eq(t, version.String(), "1.2.3")
 }
+
+func TestLibFeatures(t *testing.T) {
+	var features = objectbox.LibFeatures()
+	assert.Eq(t, objectbox.VersionLib(), features.Version)
+	assert.Eq(t, objectbox.SyncIsAvailable(), features.Sync)
+	assert.Eq(t, objectbox.AdminIsAvailable(), features.Admin)
+	assert.Eq(t, objectbox.InMemoryIsAvailable(), features.InMemory)
+	assert.True(t, !features.Encryption)
+}
+
+func TestModelMinLibVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var model = iot.ObjectBoxModel()
+	model.MinLibVersion(objectbox.VersionLibMin())
+	ob, err := objectbox.NewBuilder().Model(model).Directory(dir).BuildOrError()
+	assert.NoErr(t, err)
+	assert.Eq(t, objectbox.LibFeatures(), ob.Features())
+	ob.Close()
+
+	model = iot.ObjectBoxModel()
+	model.MinLibVersion(objectbox.Version{Major: 99})
+	_, err = objectbox.NewBuilder().Model(model).Directory(dir).BuildOrError()
+	assert.Err(t, err)
+	assert.True(t, strings.Contains(err.Error(), "99.0.0"))
+}
+
+func TestIncompatibleCoreVersionError(t *testing.T) {
+	var err error = &objectbox.IncompatibleCoreVersionError{
+		Found:  objectbox.Version{Major: 1, Minor: 2, Patch: 3},
+		Min:    objectbox.VersionLibMin(),
+		Static: objectbox.VersionLibStatic(),
+	}
+	versionErr, ok := err.(*objectbox.IncompatibleCoreVersionError)
+	assert.True(t, ok)
+	assert.True(t, versionErr.Is(objectbox.ErrIncompatibleCoreVersion))
+	assert.True(t, !versionErr.Is(objectbox.ErrStoreAlreadyOpen))
+	assert.True(t, strings.Contains(err.Error(), "1.2.3"))
+	assert.True(t, strings.Contains(err.Error(), objectbox.VersionLibMin().String()))
+}